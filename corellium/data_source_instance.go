@@ -0,0 +1,175 @@
+package corellium
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &V1InstanceDataSource{}
+	_ datasource.DataSourceWithConfigure = &V1InstanceDataSource{}
+)
+
+// NewCorelliumV1InstanceDataSource is a helper function to simplify the provider implementation.
+func NewCorelliumV1InstanceDataSource() datasource.DataSource {
+	return &V1InstanceDataSource{}
+}
+
+// V1InstanceDataSource is the data source implementation.
+type V1InstanceDataSource struct {
+	client *corellium.APIClient
+}
+
+// Metadata returns the data source type name.
+func (d *V1InstanceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1instance"
+}
+
+// Schema defines the schema for the data source. The instance is looked up by its ID, or by its name and project.
+func (d *V1InstanceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := v1InstanceDataSourceAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Description: "Instance id",
+		Optional:    true,
+		Computed:    true,
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+		},
+	}
+	attributes["name"] = schema.StringAttribute{
+		Description: "Instance name",
+		Optional:    true,
+		Computed:    true,
+		Validators: []validator.String{
+			stringvalidator.AlsoRequires(path.MatchRoot("project")),
+		},
+	}
+	attributes["project"] = schema.StringAttribute{
+		Description: "Instance project",
+		Optional:    true,
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *V1InstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config V1InstancesDataSourceInstanceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+
+	var instance corellium.Instance
+	if !config.Id.IsNull() {
+		i, r, err := d.client.InstancesApi.V1GetInstance(auth, config.Id.ValueString()).Execute()
+		if err != nil {
+			apiErr := NewAPIError(r, err)
+			if errors.Is(apiErr, ErrNotFound) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("id"),
+					"Instance not found",
+					"No instance was found with the id "+config.Id.ValueString(),
+				)
+				return
+			}
+
+			addAPIError(&resp.Diagnostics, "Unable to Read Instance", "An unexpected error was encountered trying to read the instance", apiErr)
+			return
+		}
+
+		if !config.Project.IsNull() && i.GetProject() != config.Project.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project"),
+				"Instance not found",
+				"The instance with the id "+config.Id.ValueString()+" isn't in the project "+config.Project.ValueString(),
+			)
+			return
+		}
+
+		instance = *i
+	} else {
+		instances, r, err := d.client.InstancesApi.V1GetInstances(auth).Name(config.Name.ValueString()).Execute()
+		if err != nil {
+			apiErr := NewAPIError(r, err)
+			if errors.Is(apiErr, ErrForbidden) {
+				addAPIError(&resp.Diagnostics, "Unable to Read Instance", "You do not have permission to access instances", apiErr)
+				return
+			}
+
+			addAPIError(&resp.Diagnostics, "Unable to Read Instance", "An unexpected error was encountered trying to read the instances", apiErr)
+			return
+		}
+
+		// NOTICE: The name filter of the API isn't documented to be exact, so the instances are filtered again.
+		matches := []corellium.Instance{}
+		for _, i := range instances {
+			if i.GetName() == config.Name.ValueString() && i.GetProject() == config.Project.ValueString() {
+				matches = append(matches, i)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Instance not found",
+				"No instance was found with the name "+config.Name.ValueString()+" in the project "+config.Project.ValueString(),
+			)
+			return
+		case 1:
+			instance = matches[0]
+		default:
+			ids := make([]string, len(matches))
+			for n, i := range matches {
+				ids[n] = i.GetId()
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Multiple instances found",
+				"More than one instance was found with the name "+config.Name.ValueString()+" in the project "+config.Project.ValueString()+
+					", use the id to look up one of them: "+strings.Join(ids, ", "),
+			)
+			return
+		}
+	}
+
+	state, diags := newV1InstancesDataSourceInstanceModel(ctx, instance)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *V1InstanceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
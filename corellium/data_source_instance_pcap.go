@@ -0,0 +1,144 @@
+package corellium
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &V1InstancePcapDataSource{}
+	_ datasource.DataSourceWithConfigure = &V1InstancePcapDataSource{}
+)
+
+// NewCorelliumV1InstancePcapDataSource is a helper function to simplify the provider implementation.
+func NewCorelliumV1InstancePcapDataSource() datasource.DataSource {
+	return &V1InstancePcapDataSource{}
+}
+
+// V1InstancePcapDataSource is the data source implementation. It exports the packets captured by the network monitor
+// of the instance to a local PCAP file.
+type V1InstancePcapDataSource struct {
+	client *corellium.APIClient
+}
+
+// V1InstancePcapDataSourceModel maps the data source schema data.
+type V1InstancePcapDataSourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Instance types.String `tfsdk:"instance"`
+	// OutputPath is the local path the PCAP file is written to.
+	OutputPath types.String `tfsdk:"output_path"`
+	SHA256     types.String `tfsdk:"sha256"`
+	Size       types.Int64  `tfsdk:"size"`
+}
+
+// Metadata returns the data source type name.
+func (d *V1InstancePcapDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1instance_pcap"
+}
+
+// Schema defines the schema for the data source.
+func (d *V1InstancePcapDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance id",
+				Required:    true,
+			},
+			"output_path": schema.StringAttribute{
+				Description: "Local path the PCAP file is written to",
+				Required:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 hash of the PCAP file",
+				Computed:    true,
+			},
+			"size": schema.Int64Attribute{
+				Description: "Size of the PCAP file in bytes",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read downloads the capture of the network monitor, and writes it to the output path.
+func (d *V1InstancePcapDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state V1InstancePcapDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	pcap, r, err := V1GetNetworkMonitorPcapManual(auth, d.client.GetConfig(), state.Instance.ValueString())
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			addAPIError(&resp.Diagnostics, "Unable to read capture", "No capture was found for the instance, what happens when its network monitor was never enabled", apiErr)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Unable to read capture", "An unexpected error was encountered trying to download the capture", apiErr)
+		return
+	}
+
+	output := state.OutputPath.ValueString()
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_path"),
+			"Unable to write capture",
+			"Coudn't create the directory of "+output+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := os.WriteFile(output, pcap, 0o644); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_path"),
+			"Unable to write capture",
+			"Coudn't write the capture to "+output+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(state.Instance.ValueString())
+	state.SHA256 = types.StringValue(contentSHA256(pcap))
+	state.Size = types.Int64Value(int64(len(pcap)))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *V1InstancePcapDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
+
+// V1GetNetworkMonitorPcapManual downloads the capture of the network monitor of the instance, what the API client
+// doesn't have an endpoint for.
+func V1GetNetworkMonitorPcapManual(ctx context.Context, cfg *corellium.Configuration, instanceId string) ([]byte, *http.Response, error) {
+	return doManualRequest(ctx, cfg, http.MethodGet, "/api/v1/instances/"+url.PathEscape(instanceId)+"/networkMonitor.pcap", nil)
+}
@@ -0,0 +1,79 @@
+package corellium
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"terraform-provider-corellium/corellium/pkg/mock"
+)
+
+func TestAccCorelliumV1InstancePcapDataSource(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "netmon.pcap")
+
+	config := func(enabled bool) string {
+		return providerConfig + fmt.Sprintf(`
+        resource "corellium_v1project" "test" {
+            name = "`+t.Name()+`"
+            settings = {
+                version = 1
+                internet_access = true
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+
+        resource "corellium_v1instance" "test" {
+            name = "test-netmon"
+            flavor = "iphone7plus"
+            project = corellium_v1project.test.id
+            os = "15.7.5"
+            netmon = {
+                enabled = %t
+            }
+        }
+
+        data "corellium_v1instance_pcap" "test" {
+            instance = corellium_v1instance.test.id
+            output_path = %q
+        }
+        `, enabled, output)
+	}
+
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttrPair("data.corellium_v1instance_pcap.test", "id", "corellium_v1instance.test", "id"),
+		resource.TestCheckResourceAttrSet("data.corellium_v1instance_pcap.test", "sha256"),
+	}
+	if testAccMock != nil {
+		checks = append(checks,
+			resource.TestCheckResourceAttr("data.corellium_v1instance_pcap.test", "sha256", fmt.Sprintf("%x", sha256.Sum256(mock.NetmonPcap))),
+			resource.TestCheckResourceAttr("data.corellium_v1instance_pcap.test", "size", fmt.Sprint(len(mock.NetmonPcap))),
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(append(checks,
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "on"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "netmon.enabled", "true"),
+				)...),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(append(checks,
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "netmon.enabled", "false"),
+				)...),
+			},
+		},
+	})
+}
@@ -0,0 +1,240 @@
+package corellium
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &V1InstanceReadyDataSource{}
+	_ datasource.DataSourceWithConfigure = &V1InstanceReadyDataSource{}
+)
+
+// NewCorelliumV1InstanceReadyDataSource is a helper function to simplify the provider implementation.
+func NewCorelliumV1InstanceReadyDataSource() datasource.DataSource {
+	return &V1InstanceReadyDataSource{}
+}
+
+// V1InstanceReadyDataSource is the data source implementation. It blocks until the instance is ready, so the
+// resources and provisioners that depend on it run on a booted device.
+type V1InstanceReadyDataSource struct {
+	client *corellium.APIClient
+}
+
+// V1InstanceReadyDataSourceModel maps the data source schema data.
+type V1InstanceReadyDataSourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Instance types.String `tfsdk:"instance"`
+	// WaitFor is what is waited for: the instance to be on, or also its agent to be ready, what is the default.
+	WaitFor types.String `tfsdk:"wait_for"`
+	// BootProperty is the Android system property that, when it has BootPropertyValue, tells the boot is completed.
+	BootProperty      types.String `tfsdk:"boot_property"`
+	BootPropertyValue types.String `tfsdk:"boot_property_value"`
+	State             types.String `tfsdk:"state"`
+	AgentReady        types.Bool   `tfsdk:"agent_ready"`
+	// Timeouts is the time to wait for the instance to be ready.
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+const (
+	// V1InstanceReadyDefaultReadTimeout is the default time to wait for an instance to be ready.
+	V1InstanceReadyDefaultReadTimeout = 15 * time.Minute
+	// V1InstanceReadyDefaultBootPropertyValue is the value of the boot property when the boot is completed.
+	V1InstanceReadyDefaultBootPropertyValue = "1"
+)
+
+// Metadata returns the data source type name.
+func (d *V1InstanceReadyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1instance_ready"
+}
+
+// Schema defines the schema for the data source.
+func (d *V1InstanceReadyDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance id",
+				Required:    true,
+			},
+			"wait_for": schema.StringAttribute{
+				Description: "Wait for the instance to be on, or also for its agent to be ready. Default is agent",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(V1InstanceWaitForState, V1InstanceWaitForAgent),
+				},
+			},
+			"boot_property": schema.StringAttribute{
+				Description: "Android system property to wait for after the agent is ready, e.g. sys.boot_completed",
+				Optional:    true,
+			},
+			"boot_property_value": schema.StringAttribute{
+				Description: "Value of the boot property when the boot is completed. Default is 1",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("boot_property")),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description: "Instance state",
+				Computed:    true,
+			},
+			"agent_ready": schema.BoolAttribute{
+				Description: "Instance agent ready",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// Read waits for the instance to be ready, and sets its state.
+func (d *V1InstanceReadyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state V1InstanceReadyDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, V1InstanceReadyDefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	id := state.Instance.ValueString()
+
+	stateConf := &retry.StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			instance, r, err := d.client.InstancesApi.V1GetInstance(auth, id).Execute()
+			if err != nil {
+				return nil, "", NewAPIError(r, err)
+			}
+
+			return instance, string(instance.GetState()), nil
+		},
+		// NOTICE: An instance that is off, or paused, won't be on by itself, so it isn't waited for.
+		Pending: []string{
+			V1InstanceStateCreating,
+			V1InstanceStateBooting,
+			V1InstanceStateRebooting,
+			V1InstanceStateRestoring,
+		},
+		Target:     []string{V1InstanceStateOn},
+		MinTimeout: pollWait(5 * time.Second),
+		Timeout:    timeout,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			addAPIError(&resp.Diagnostics, "Error waiting for instance", "An unexpected error was encountered trying to read the instance", apiErr)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error waiting for instance",
+			"Coudn't wait for the instance to be on: "+err.Error(),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(id)
+	state.State = types.StringValue(V1InstanceStateOn)
+	state.AgentReady = types.BoolValue(false)
+
+	if state.WaitFor.ValueString() != V1InstanceWaitForState {
+		if err := waitForInstanceAgent(ctx, d.client, auth, id, timeout); err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for instance",
+				"Coudn't wait for the instance agent to be ready: "+err.Error(),
+			)
+			return
+		}
+
+		state.AgentReady = types.BoolValue(true)
+	}
+
+	if !state.BootProperty.IsNull() {
+		value := V1InstanceReadyDefaultBootPropertyValue
+		if !state.BootPropertyValue.IsNull() {
+			value = state.BootPropertyValue.ValueString()
+		}
+
+		if err := d.waitForBootProperty(ctx, auth, id, state.BootProperty.ValueString(), value, timeout); err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for instance",
+				"Coudn't wait for the instance to complete the boot: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// waitForBootProperty waits until the Android system property of the instance has the value, or the timeout expires.
+func (d *V1InstanceReadyDataSource) waitForBootProperty(ctx context.Context, auth context.Context, id, property, value string, timeout time.Duration) error {
+	const (
+		pending = "pending"
+		done    = "done"
+	)
+
+	stateConf := &retry.StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			v, r, err := d.client.AgentApi.V1AgentSystemGetProp(auth, id).AgentSystemGetPropBody(*corellium.NewAgentSystemGetPropBody(property)).Execute()
+			if err != nil {
+				return nil, "", NewAPIError(r, err)
+			}
+
+			if v.GetValue() != value {
+				return v, pending, nil
+			}
+
+			return v, done, nil
+		},
+		Pending:    []string{pending},
+		Target:     []string{done},
+		MinTimeout: pollWait(5 * time.Second),
+		Timeout:    timeout,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *V1InstanceReadyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
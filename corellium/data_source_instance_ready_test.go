@@ -0,0 +1,60 @@
+package corellium
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCorelliumV1InstanceReadyDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+                resource "corellium_v1project" "test" {
+                    name = "` + t.Name() + `"
+                    settings = {
+                        version = 1
+                        internet_access = true
+                        dhcp = false
+                    }
+                    quotas = {
+                        cores = 8
+                    }
+                    users = []
+                    teams = []
+                    keys  = []
+                }
+
+                resource "corellium_v1instance" "ios" {
+                    name = "test-ios"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    wait_for = "agent"
+                }
+
+                resource "corellium_v1instance" "android" {
+                    name = "test-android"
+                    flavor = "ranchu"
+                    project = corellium_v1project.test.id
+                    os = "13.0.0"
+                }
+
+                data "corellium_v1instance_ready" "android" {
+                    instance = corellium_v1instance.android.id
+                    boot_property = "sys.boot_completed"
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.ios", "wait_for", "agent"),
+					resource.TestCheckResourceAttr("corellium_v1instance.ios", "state", "on"),
+					resource.TestCheckResourceAttrPair("data.corellium_v1instance_ready.android", "id", "corellium_v1instance.android", "id"),
+					resource.TestCheckResourceAttr("data.corellium_v1instance_ready.android", "state", "on"),
+					resource.TestCheckResourceAttr("data.corellium_v1instance_ready.android", "agent_ready", "true"),
+				),
+			},
+		},
+	})
+}
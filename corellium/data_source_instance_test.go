@@ -0,0 +1,93 @@
+package corellium
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCorelliumV1InstanceDataSource(t *testing.T) {
+	config := providerConfig + `
+    resource "corellium_v1project" "test" {
+        name = "` + t.Name() + `"
+        settings = {
+            version = 1
+            internet_access = true
+            dhcp = false
+        }
+        quotas = {
+            cores = 4
+        }
+        users = []
+        teams = []
+        keys  = []
+    }
+
+    resource "corellium_v1instance" "test" {
+        name = "test-instance-data-source"
+        flavor = "iphone7plus"
+        project = corellium_v1project.test.id
+        os = "15.7.5"
+        wait_for_ready = true
+    }
+    `
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+                data "corellium_v1instance" "by_id" {
+                    id = corellium_v1instance.test.id
+                }
+
+                data "corellium_v1instance" "by_name" {
+                    name = corellium_v1instance.test.name
+                    project = corellium_v1project.test.id
+                }
+
+                data "corellium_v1instances" "filtered" {
+                    project = corellium_v1project.test.id
+                    flavor = "iphone7plus"
+                    state = "on"
+                    name_regex = "^test-instance-"
+
+                    depends_on = [corellium_v1instance.test]
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.corellium_v1instance.by_id", "name", "corellium_v1instance.test", "name"),
+					resource.TestCheckResourceAttrPair("data.corellium_v1instance.by_id", "project", "corellium_v1project.test", "id"),
+					resource.TestCheckResourceAttr("data.corellium_v1instance.by_id", "flavor", "iphone7plus"),
+					resource.TestCheckResourceAttrPair("data.corellium_v1instance.by_name", "id", "corellium_v1instance.test", "id"),
+					resource.TestCheckResourceAttr("data.corellium_v1instance.by_name", "os", "15.7.5"),
+					resource.TestCheckResourceAttrSet("corellium_v1instance.test", "services.vpn.proxy.#"),
+					resource.TestCheckResourceAttrPair("data.corellium_v1instance.by_id", "services.vpn.proxy.#", "corellium_v1instance.test", "services.vpn.proxy.#"),
+					resource.TestCheckResourceAttr("data.corellium_v1instances.filtered", "instances.#", "1"),
+					resource.TestCheckResourceAttrPair("data.corellium_v1instances.filtered", "instances.0.id", "corellium_v1instance.test", "id"),
+				),
+			},
+			{
+				Config: config + `
+                data "corellium_v1instances" "filtered" {
+                    project = corellium_v1project.test.id
+                    name_regex = "^no-such-instance$"
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.corellium_v1instances.filtered", "instances.#", "0"),
+				),
+			},
+			{
+				Config: config + `
+                data "corellium_v1instance" "missing" {
+                    name = "no-such-instance"
+                    project = corellium_v1project.test.id
+                }
+                `,
+				ExpectError: regexp.MustCompile("Instance not found"),
+			},
+		},
+	})
+}
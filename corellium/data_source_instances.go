@@ -2,13 +2,16 @@ package corellium
 
 import (
 	"context"
-	"net/http"
+	"errors"
+	"regexp"
 
 	"github.com/aimoda/go-corellium-api-client"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-corellium/corellium/pkg/api"
@@ -32,8 +35,70 @@ type V1InstancesDataSource struct {
 
 // V1InstancesDataSourceModel maps the data source schema data.
 type V1InstancesDataSourceModel struct {
-	Id        types.String      `tfsdk:"id"`
-	Instances []V1InstanceModel `tfsdk:"instances"`
+	Id        types.String                         `tfsdk:"id"`
+	Project   types.String                         `tfsdk:"project"`
+	Flavor    types.String                         `tfsdk:"flavor"`
+	State     types.String                         `tfsdk:"state"`
+	NameRegex types.String                         `tfsdk:"name_regex"`
+	Instances []V1InstancesDataSourceInstanceModel `tfsdk:"instances"`
+}
+
+// v1InstanceStates are the states an instance can be in.
+var v1InstanceStates = []string{
+	string(corellium.ON),
+	string(corellium.OFF),
+	string(corellium.BOOTING),
+	string(corellium.DELETING),
+	string(corellium.CREATING),
+	string(corellium.RESTORING),
+	string(corellium.PAUSED),
+	string(corellium.REBOOTING),
+	string(corellium.ERROR),
+}
+
+// V1InstancesDataSourceInstanceModel maps an instance of the data source schema data. Unlike V1InstanceModel, it
+// doesn't have the create options and the timeouts, what only make sense for the resource.
+type V1InstancesDataSourceInstanceModel struct {
+	Id           types.String                           `tfsdk:"id"`
+	Name         types.String                           `tfsdk:"name"`
+	Key          types.String                           `tfsdk:"key"`
+	Flavor       types.String                           `tfsdk:"flavor"`
+	Type         types.String                           `tfsdk:"type"`
+	Project      types.String                           `tfsdk:"project"`
+	State        types.String                           `tfsdk:"state"`
+	StateChanged types.String                           `tfsdk:"state_changed"`
+	StartedAt    types.String                           `tfsdk:"started_at"`
+	UserTask     types.String                           `tfsdk:"user_task"`
+	TaskState    types.String                           `tfsdk:"task_state"`
+	Error        types.String                           `tfsdk:"error"`
+	BootOptions  *V1InstancesDataSourceBootOptionsModel `tfsdk:"boot_options"`
+	ServiceIP    types.String                           `tfsdk:"service_ip"`
+	WifiIP       types.String                           `tfsdk:"wifi_ip"`
+	SecondaryIP  types.String                           `tfsdk:"secondary_ip"`
+	Services     *V1InstanceServicesModel               `tfsdk:"services"`
+	Panicked     types.Bool                             `tfsdk:"panicked"`
+	Created      types.String                           `tfsdk:"created"`
+	Model        types.String                           `tfsdk:"model"`
+	FWPackage    types.String                           `tfsdk:"fwpackage"`
+	OS           types.String                           `tfsdk:"os"`
+	Agent        *V1InstanceAgentModel                  `tfsdk:"agent"`
+	Netmon       *V1InstanceNetmonModel                 `tfsdk:"netmon"`
+	ExposePort   types.String                           `tfsdk:"expose_port"`
+	Fault        types.Bool                             `tfsdk:"fault"`
+	Patches      types.List                             `tfsdk:"patches"`
+	CreatedBy    *V1InstanceCreatedByModel              `tfsdk:"created_by"`
+}
+
+// V1InstancesDataSourceBootOptionsModel maps the boot options of an instance of the data source schema data.
+type V1InstancesDataSourceBootOptionsModel struct {
+	BootArgs        types.String `tfsdk:"boot_args"`
+	RestoreBootArgs types.String `tfsdk:"restore_boot_args"`
+	UDID            types.String `tfsdk:"udid"`
+	ECID            types.String `tfsdk:"ecid"`
+	RandomSeed      types.String `tfsdk:"random_seed"`
+	PAC             types.Bool   `tfsdk:"pac"`
+	APRR            types.Bool   `tfsdk:"aprr"`
+	AdditionalTags  types.List   `tfsdk:"additional_tags"`
 }
 
 // Metadata returns the data source type name.
@@ -48,232 +113,269 @@ func (d *V1InstancesDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"project": schema.StringAttribute{
+				Description: "Only list the instances of the project",
+				Optional:    true,
+			},
+			"flavor": schema.StringAttribute{
+				Description: "Only list the instances of the flavor",
+				Optional:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "Only list the instances in the state",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(v1InstanceStates...),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only list the instances whose name matches the regular expression",
+				Optional:    true,
+			},
 			"instances": schema.ListNestedAttribute{
-				Optional: true,
+				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
+					Attributes: v1InstanceDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// v1InstanceDataSourceVPNPortAttributes returns the attributes of a port of the instance VPN read by a data source.
+func v1InstanceDataSourceVPNPortAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"device_port": schema.Int64Attribute{
+			Description: "Port on the device",
+			Computed:    true,
+		},
+		"router_port": schema.Int64Attribute{
+			Description: "Port on the project router",
+			Computed:    true,
+		},
+		"expose": schema.BoolAttribute{
+			Description: "Port exposed to the external interface",
+			Computed:    true,
+		},
+		"first_available": schema.BoolAttribute{
+			Description: "First available port used if the device port isn't available",
+			Computed:    true,
+		},
+	}
+}
+
+// v1InstanceDataSourceAttributes returns the attributes of an instance read by a data source.
+func v1InstanceDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "Instance id",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Instance name",
+			Computed:    true,
+		},
+		"key": schema.StringAttribute{
+			Description: "Instance key",
+			Computed:    true,
+		},
+		"flavor": schema.StringAttribute{
+			Description: "Instance flavor",
+			Computed:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "Instance type",
+			Computed:    true,
+		},
+		"project": schema.StringAttribute{
+			Description: "Instance project",
+			Computed:    true,
+		},
+		"state": schema.StringAttribute{
+			Description: "Instance state",
+			Computed:    true,
+		},
+		"state_changed": schema.StringAttribute{
+			Description: "Instance state changed",
+			Computed:    true,
+		},
+		"started_at": schema.StringAttribute{
+			Description: "Instance started at",
+			Computed:    true,
+		},
+		"user_task": schema.StringAttribute{
+			Description: "Instance user task",
+			Computed:    true,
+		},
+		"task_state": schema.StringAttribute{
+			Description: "Instance task state",
+			Computed:    true,
+		},
+		"error": schema.StringAttribute{
+			Description: "Instance error",
+			Computed:    true,
+		},
+		"boot_options": schema.SingleNestedAttribute{
+			Description: "Instance boot options",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"boot_args": schema.StringAttribute{
+					Description: "Instance boot args",
+					Computed:    true,
+				},
+				"restore_boot_args": schema.StringAttribute{
+					Description: "Instance restore boot args",
+					Computed:    true,
+				},
+				"udid": schema.StringAttribute{
+					Description: "Instance boot options udid",
+					Computed:    true,
+				},
+				"ecid": schema.StringAttribute{
+					Description: "Instance boot options ecid",
+					Computed:    true,
+				},
+				"random_seed": schema.StringAttribute{
+					Description: "Instance boot options random seed",
+					Computed:    true,
+				},
+				"pac": schema.BoolAttribute{
+					Description: "Instance boot options pac",
+					Computed:    true,
+				},
+				"aprr": schema.BoolAttribute{
+					Description: "Instance boot options aprr",
+					Computed:    true,
+				},
+				"additional_tags": schema.ListAttribute{
+					// TODO: add validation to this list.
+					Description: "Instance boot options additional tags",
+					ElementType: types.StringType,
+					Computed:    true,
+				},
+			},
+		},
+		"service_ip": schema.StringAttribute{
+			Description: "Instance service ip",
+			Computed:    true,
+		},
+		"wifi_ip": schema.StringAttribute{
+			Description: "Instance wifi ip",
+			Computed:    true,
+		},
+		"secondary_ip": schema.StringAttribute{
+			Description: "Instance secondary ip",
+			Computed:    true,
+		},
+		"services": schema.SingleNestedAttribute{
+			Description: "Instance services",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"vpn": schema.SingleNestedAttribute{
+					Description: "Instance services vpn",
+					Computed:    true,
 					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							Description: "Instance id",
-							Computed:    true,
-						},
-						"name": schema.StringAttribute{
-							Description: "Instance name",
-							Optional:    true,
-							Computed:    true,
-						},
-						"key": schema.StringAttribute{
-							Description: "Instance key",
-							Computed:    true,
-						},
-						"flavor": schema.StringAttribute{
-							Description: "Instance flavor",
-							Required:    true,
-						},
-						"type": schema.StringAttribute{
-							Description: "Instance type",
-							Computed:    true,
-						},
-						"project": schema.StringAttribute{
-							Description: "Instance project",
-							Required:    true,
-						},
-						"state": schema.StringAttribute{
-							Description: "Instance state",
-							Optional:    true,
-							Computed:    true,
-							Validators: []validator.String{
-								stringvalidator.OneOf("on", "off", "paused"),
-							},
-						},
-						"state_changed": schema.StringAttribute{
-							Description: "Instance state changed",
-							Computed:    true,
-						},
-						"started_at": schema.StringAttribute{
-							Description: "Instance started at",
-							Computed:    true,
-						},
-						"user_task": schema.StringAttribute{
-							Description: "Instance user task",
-							Computed:    true,
-						},
-						"task_state": schema.StringAttribute{
-							Description: "Instance task state",
-							Computed:    true,
-						},
-						"error": schema.StringAttribute{
-							Description: "Instance error",
-							Computed:    true,
-						},
-						"boot_options": schema.SingleNestedAttribute{
-							Description: "Instance boot options",
-							Computed:    true,
-							Attributes: map[string]schema.Attribute{
-								"boot_args": schema.StringAttribute{
-									Description: "Instance boot args",
-									Computed:    true,
-								},
-								"restore_boot_args": schema.StringAttribute{
-									Description: "Instance restore boot args",
-									Computed:    true,
-								},
-								"udid": schema.StringAttribute{
-									Description: "Instance boot options udid",
-									Computed:    true,
-								},
-								"ecid": schema.StringAttribute{
-									Description: "Instance boot options ecid",
-									Computed:    true,
-								},
-								"random_seed": schema.StringAttribute{
-									Description: "Instance boot options random seed",
-									Computed:    true,
-								},
-								"pac": schema.BoolAttribute{
-									Description: "Instance boot options pac",
-									Computed:    true,
-								},
-								"aprr": schema.BoolAttribute{
-									Description: "Instance boot options aprr",
-									Computed:    true,
-								},
-								"additional_tags": schema.ListAttribute{
-									// TODO: add validation to this list.
-									Description: "Instance boot options additional tags",
-									ElementType: types.StringType,
-									Computed:    true,
-								},
-							},
-						},
-						"service_ip": schema.StringAttribute{
-							Description: "Instance service ip",
-							Computed:    true,
-						},
-						"wifi_ip": schema.StringAttribute{
-							Description: "Instance wifi ip",
-							Computed:    true,
-						},
-						"secondary_ip": schema.StringAttribute{
-							Description: "Instance secondary ip",
-							Computed:    true,
-						},
-						/*"services": schema.SingleNestedAttribute{ // TODO: find the right type for this.
-							Description: "Instance services",
-							Computed:    true,
-							Attributes: map[string]schema.Attribute{
-								"vpn": schema.SingleNestedAttribute{
-									Description: "Instance services vpn",
-									Computed:    true,
-									Attributes: map[string]schema.Attribute{
-										"proxy": schema.MapAttribute{
-											Computed: true,
-										},
-										"listeners": schema.MapAttribute{
-											Computed: true,
-										},
-									},
-								},
-							},
-						},*/
-						"panicked": schema.BoolAttribute{
-							Description: "Instance panicked",
-							Computed:    true,
-						},
-						"created": schema.StringAttribute{
-							Description: "Instance created",
-							Computed:    true,
-						},
-						"model": schema.StringAttribute{
-							Description: "Instance model",
-							Computed:    true,
-						},
-						"fwpackage": schema.StringAttribute{
-							Description: "Instance fwpackage",
-							Computed:    true,
-						},
-						"os": schema.StringAttribute{
-							Description: "Instance os",
-							Required:    true,
-						},
-						"agent": schema.SingleNestedAttribute{
-							Description: "Instance agent",
-							Computed:    true,
-							Attributes: map[string]schema.Attribute{
-								"hash": schema.StringAttribute{
-									Description: "Instance agent hash",
-									Computed:    true,
-								},
-								"info": schema.StringAttribute{
-									Description: "Instance agent info",
-									Computed:    true,
-								},
-							},
-						},
-						"netmon": schema.SingleNestedAttribute{
-							Description: "Instance agent",
-							Computed:    true,
-							Attributes: map[string]schema.Attribute{
-								"hash": schema.StringAttribute{
-									Description: "Instance netmon hash",
-									Computed:    true,
-								},
-								"info": schema.StringAttribute{
-									Description: "Instance netmon info",
-									Computed:    true,
-								},
-								"enabled": schema.BoolAttribute{
-									Description: "Instance netmon enabled",
-									Computed:    true,
-								},
-							},
-						},
-						"expose_port": schema.StringAttribute{
-							Description: "Instance expose port",
-							Computed:    true,
-						},
-						"fault": schema.BoolAttribute{
-							Description: "Instance fault",
-							Computed:    true,
-						},
-						"patches": schema.ListAttribute{
-							Description: "Instance patches",
-							Computed:    true,
-							ElementType: types.StringType,
-						},
-						"created_by": schema.SingleNestedAttribute{
-							Description: "Instance created by",
-							Computed:    true,
-							Attributes: map[string]schema.Attribute{
-								"id": schema.StringAttribute{
-									Description: "Instance user id",
-									Computed:    true,
-								},
-								"username": schema.StringAttribute{
-									Description: "Instance user username",
-									Computed:    true,
-								},
-								"label": schema.StringAttribute{
-									Description: "Instance user label",
-									Computed:    true,
-								},
-								"deleted": schema.BoolAttribute{
-									Description: "Instance user deleted status",
-									Computed:    true,
-								},
-							},
+						"proxy": schema.ListNestedAttribute{
+							Description:  "Instance vpn proxied ports",
+							Computed:     true,
+							NestedObject: schema.NestedAttributeObject{Attributes: v1InstanceDataSourceVPNPortAttributes()},
 						},
-						"wait_for_ready": schema.BoolAttribute{
-							Description: "Wait for ready",
-							Optional:    true,
-						},
-						"wait_for_ready_timeout": schema.Int64Attribute{
-							Description: "Wait for ready timeout",
-							Optional:    true,
+						"listeners": schema.ListNestedAttribute{
+							Description:  "Instance vpn listened ports",
+							Computed:     true,
+							NestedObject: schema.NestedAttributeObject{Attributes: v1InstanceDataSourceVPNPortAttributes()},
 						},
 					},
 				},
 			},
 		},
+		"panicked": schema.BoolAttribute{
+			Description: "Instance panicked",
+			Computed:    true,
+		},
+		"created": schema.StringAttribute{
+			Description: "Instance created",
+			Computed:    true,
+		},
+		"model": schema.StringAttribute{
+			Description: "Instance model",
+			Computed:    true,
+		},
+		"fwpackage": schema.StringAttribute{
+			Description: "Instance fwpackage",
+			Computed:    true,
+		},
+		"os": schema.StringAttribute{
+			Description: "Instance os",
+			Computed:    true,
+		},
+		"agent": schema.SingleNestedAttribute{
+			Description: "Instance agent",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"hash": schema.StringAttribute{
+					Description: "Instance agent hash",
+					Computed:    true,
+				},
+				"info": schema.StringAttribute{
+					Description: "Instance agent info",
+					Computed:    true,
+				},
+			},
+		},
+		"netmon": schema.SingleNestedAttribute{
+			Description: "Instance agent",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"hash": schema.StringAttribute{
+					Description: "Instance netmon hash",
+					Computed:    true,
+				},
+				"info": schema.StringAttribute{
+					Description: "Instance netmon info",
+					Computed:    true,
+				},
+				"enabled": schema.BoolAttribute{
+					Description: "Instance netmon enabled",
+					Computed:    true,
+				},
+			},
+		},
+		"expose_port": schema.StringAttribute{
+			Description: "Instance expose port",
+			Computed:    true,
+		},
+		"fault": schema.BoolAttribute{
+			Description: "Instance fault",
+			Computed:    true,
+		},
+		"patches": schema.ListAttribute{
+			Description: "Instance patches",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"created_by": schema.SingleNestedAttribute{
+			Description: "Instance created by",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Description: "Instance user id",
+					Computed:    true,
+				},
+				"username": schema.StringAttribute{
+					Description: "Instance user username",
+					Computed:    true,
+				},
+				"label": schema.StringAttribute{
+					Description: "Instance user label",
+					Computed:    true,
+				},
+				"deleted": schema.BoolAttribute{
+					Description: "Instance user deleted status",
+					Computed:    true,
+				},
+			},
+		},
 	}
 }
 
@@ -281,20 +383,36 @@ func (d *V1InstancesDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 func (d *V1InstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state V1InstancesDataSourceModel
 
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name_regex",
+				"The name_regex isn't a valid regular expression: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	instances, r, err := d.client.InstancesApi.V1GetInstances(auth).Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Unable to Read Instances",
-				"You do not have permission to access instances",
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Unable to Read Instances", "You do not have permission to access instances", apiErr)
 			return
 		}
-		resp.Diagnostics.AddError(
-			"Unable to Read Instances",
-			err.Error(),
-		)
+
+		addAPIError(&resp.Diagnostics, "Unable to Read Instances", "An unexpected error was encountered trying to read the instances", apiErr)
 		return
 	}
 
@@ -308,101 +426,122 @@ func (d *V1InstancesDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	state.Id = types.StringValue(id)
-	state.Instances = make([]V1InstanceModel, len(instances))
-	for i, instance := range instances {
-		state.Instances[i].Id = types.StringValue(instance.GetId())
-		state.Instances[i].Name = types.StringValue(instance.GetName())
-		state.Instances[i].Key = types.StringValue(instance.GetKey())
-		state.Instances[i].Flavor = types.StringValue(instance.GetFlavor())
-		state.Instances[i].Type = types.StringValue(instance.GetType())
-		state.Instances[i].Project = types.StringValue(instance.GetProject())
-		state.Instances[i].State = types.StringValue(string(instance.GetState()))
-		state.Instances[i].StateChanged = types.StringValue(instance.GetStateChanged().UTC().String())
-		state.Instances[i].StartedAt = types.StringValue(instance.GetStartedAt())
-		state.Instances[i].UserTask = types.StringValue(instance.GetUserTask())
-		state.Instances[i].TaskState = types.StringValue(instance.GetTaskState())
-		state.Instances[i].Error = types.StringValue(instance.GetError())
-
-		additionalTags, diags := types.ListValueFrom(ctx, types.StringType, instance.BootOptions.GetAdditionalTags())
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+	state.Instances = []V1InstancesDataSourceInstanceModel{}
+	for _, instance := range instances {
+		if !state.Project.IsNull() && instance.GetProject() != state.Project.ValueString() {
+			continue
 		}
 
-		state.Instances[i].BootOptions = &V1InstanceBootOptionsModel{
-			BootArgs:        types.StringValue(instance.BootOptions.GetBootArgs()),
-			RestoreBootArgs: types.StringValue(instance.BootOptions.GetRestoreBootArgs()),
-			UDID:            types.StringValue(instance.BootOptions.GetUdid()),
-			ECID:            types.StringValue(instance.BootOptions.GetEcid()),
-			RandomSeed:      types.StringValue(instance.BootOptions.GetRandomSeed()),
-			PAC:             types.BoolValue(instance.BootOptions.GetPac()),
-			APRR:            types.BoolValue(instance.BootOptions.GetAprr()),
-			AdditionalTags:  additionalTags,
+		if !state.Flavor.IsNull() && instance.GetFlavor() != state.Flavor.ValueString() {
+			continue
 		}
 
-		state.Instances[i].ServiceIP = types.StringValue(instance.GetServiceIp())
-		state.Instances[i].WifiIP = types.StringValue(instance.GetWifiIp())
-		state.Instances[i].SecondaryIP = types.StringValue(instance.GetSecondaryIp())
-
-		/*proxy, diags := types.MapValueFrom(ctx, types.StringType, instance.Services.GetVpn().Proxy) // TODO: find the right type for this.
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		if !state.State.IsNull() && string(instance.GetState()) != state.State.ValueString() {
+			continue
 		}
 
-		listeners, diags := types.MapValueFrom(ctx, types.StringType, instance.Services.GetVpn().Listeners)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		if nameRegex != nil && !nameRegex.MatchString(instance.GetName()) {
+			continue
 		}
 
-		state.Services = &V1InstanceServicesModel{
-			VPN: &V1InstanceVPNModel{
-				Proxy:     proxy,
-				Listeners: listeners,
-			},
-		}*/
-
-		state.Instances[i].Panicked = types.BoolValue(instance.GetPanicked())
-		state.Instances[i].Created = types.StringValue(instance.GetCreated().UTC().String())
-		state.Instances[i].Model = types.StringValue(instance.GetModel())
-		state.Instances[i].FWPackage = types.StringValue(instance.GetFwpackage())
-		state.Instances[i].OS = types.StringValue(instance.GetOs())
-		state.Instances[i].Agent = &V1InstanceAgentModel{
-			Hash: types.StringValue(instance.Agent.Get().GetHash()),
-			Info: types.StringValue(instance.Agent.Get().GetInfo()),
-		}
-		state.Instances[i].Netmon = &V1InstanceNetmonModel{
-			Hash:    types.StringValue(instance.Netmon.Get().GetHash()),
-			Info:    types.StringValue(instance.Netmon.Get().GetInfo()),
-			Enabled: types.BoolValue(instance.Netmon.Get().GetEnabled()),
-		}
-		state.Instances[i].ExposePort = types.StringValue(instance.GetExposePort())
-		state.Instances[i].Fault = types.BoolValue(instance.GetFault())
-
-		patches, diags := types.ListValueFrom(ctx, types.StringType, instance.GetPatches())
+		m, diags := newV1InstancesDataSourceInstanceModel(ctx, instance)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		state.Instances[i].Patches = patches
-
-		state.Instances[i].CreatedBy = &V1InstanceCreatedByModel{
-			Id:       types.StringValue(instance.CreatedBy.GetId()),
-			Username: types.StringValue(instance.CreatedBy.GetUsername()),
-			Label:    types.StringValue(instance.CreatedBy.GetLabel()),
-			Deleted:  types.BoolValue(instance.CreatedBy.GetDeleted()),
-		}
+		state.Instances = append(state.Instances, m)
 	}
 
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// newV1InstancesDataSourceInstanceModel returns the data source model of the instance.
+func newV1InstancesDataSourceInstanceModel(ctx context.Context, instance corellium.Instance) (V1InstancesDataSourceInstanceModel, diag.Diagnostics) {
+	var m V1InstancesDataSourceInstanceModel
+
+	m.Id = types.StringValue(instance.GetId())
+	m.Name = types.StringValue(instance.GetName())
+	m.Key = types.StringValue(instance.GetKey())
+	m.Flavor = types.StringValue(instance.GetFlavor())
+	m.Type = types.StringValue(instance.GetType())
+	m.Project = types.StringValue(instance.GetProject())
+	m.State = types.StringValue(string(instance.GetState()))
+	m.StateChanged = types.StringValue(instance.GetStateChanged().UTC().String())
+	m.StartedAt = types.StringValue(instance.GetStartedAt())
+	m.UserTask = types.StringValue(instance.GetUserTask())
+	m.TaskState = types.StringValue(instance.GetTaskState())
+	m.Error = types.StringValue(instance.GetError())
+
+	additionalTags, diags := types.ListValueFrom(ctx, types.StringType, instance.BootOptions.GetAdditionalTags())
+	if diags.HasError() {
+		return m, diags
+	}
+
+	m.BootOptions = &V1InstancesDataSourceBootOptionsModel{
+		BootArgs:        types.StringValue(instance.BootOptions.GetBootArgs()),
+		RestoreBootArgs: types.StringValue(instance.BootOptions.GetRestoreBootArgs()),
+		UDID:            types.StringValue(instance.BootOptions.GetUdid()),
+		ECID:            types.StringValue(instance.BootOptions.GetEcid()),
+		RandomSeed:      types.StringValue(instance.BootOptions.GetRandomSeed()),
+		PAC:             types.BoolValue(instance.BootOptions.GetPac()),
+		APRR:            types.BoolValue(instance.BootOptions.GetAprr()),
+		AdditionalTags:  additionalTags,
+	}
+
+	m.ServiceIP = types.StringValue(instance.GetServiceIp())
+	m.WifiIP = types.StringValue(instance.GetWifiIp())
+	m.SecondaryIP = types.StringValue(instance.GetSecondaryIp())
+
+	services, err := newV1InstanceServicesModel(&instance)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Instance",
+			"Coudn't read the VPN ports of the instance "+instance.GetId()+": "+err.Error(),
+		)
+		return m, diags
+	}
+
+	m.Services = services
+
+	m.Panicked = types.BoolValue(instance.GetPanicked())
+	m.Created = types.StringValue(instance.GetCreated().UTC().String())
+	m.Model = types.StringValue(instance.GetModel())
+	m.FWPackage = types.StringValue(instance.GetFwpackage())
+	m.OS = types.StringValue(instance.GetOs())
+	m.Agent = &V1InstanceAgentModel{
+		Hash: types.StringValue(instance.Agent.Get().GetHash()),
+		Info: types.StringValue(instance.Agent.Get().GetInfo()),
+	}
+	m.Netmon = &V1InstanceNetmonModel{
+		Hash:    types.StringValue(instance.Netmon.Get().GetHash()),
+		Info:    types.StringValue(instance.Netmon.Get().GetInfo()),
+		Enabled: types.BoolValue(instance.Netmon.Get().GetEnabled()),
+	}
+	m.ExposePort = types.StringValue(instance.GetExposePort())
+	m.Fault = types.BoolValue(instance.GetFault())
+
+	patches, diags := types.ListValueFrom(ctx, types.StringType, instance.GetPatches())
+	if diags.HasError() {
+		return m, diags
+	}
+
+	m.Patches = patches
+
+	m.CreatedBy = &V1InstanceCreatedByModel{
+		Id:       types.StringValue(instance.CreatedBy.GetId()),
+		Username: types.StringValue(instance.CreatedBy.GetUsername()),
+		Label:    types.StringValue(instance.CreatedBy.GetLabel()),
+		Deleted:  types.BoolValue(instance.CreatedBy.GetDeleted()),
+	}
+
+	return m, nil
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *V1InstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
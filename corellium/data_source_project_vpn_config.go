@@ -0,0 +1,163 @@
+package corellium
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &V1ProjectVPNConfigDataSource{}
+	_ datasource.DataSourceWithConfigure = &V1ProjectVPNConfigDataSource{}
+)
+
+// NewCorelliumV1ProjectVPNConfigDataSource is a helper function to simplify the provider implementation.
+func NewCorelliumV1ProjectVPNConfigDataSource() datasource.DataSource {
+	return &V1ProjectVPNConfigDataSource{}
+}
+
+// V1ProjectVPNConfigDataSource is the data source implementation. It downloads the VPN profile of a project, what
+// connects to the devices of the project through their VPN ports.
+type V1ProjectVPNConfigDataSource struct {
+	client *corellium.APIClient
+}
+
+// V1ProjectVPNConfigDataSourceModel maps the data source schema data.
+type V1ProjectVPNConfigDataSourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	Project types.String `tfsdk:"project"`
+	// Format is the format of the profile. The API only has OpenVPN profiles, what is the default.
+	Format types.String `tfsdk:"format"`
+	// OutputPath is the local path the profile is written to, when it is set.
+	OutputPath types.String `tfsdk:"output_path"`
+	Config     types.String `tfsdk:"config"`
+	SHA256     types.String `tfsdk:"sha256"`
+}
+
+// V1ProjectVPNConfigFormatOVPN is the format of an OpenVPN profile.
+const V1ProjectVPNConfigFormatOVPN = "ovpn"
+
+// Metadata returns the data source type name.
+func (d *V1ProjectVPNConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1project_vpn_config"
+}
+
+// Schema defines the schema for the data source.
+func (d *V1ProjectVPNConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"project": schema.StringAttribute{
+				Description: "Project id",
+				Required:    true,
+			},
+			"format": schema.StringAttribute{
+				Description: "Profile format. Default is ovpn",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(V1ProjectVPNConfigFormatOVPN),
+				},
+			},
+			"output_path": schema.StringAttribute{
+				Description: "Local path the profile is written to",
+				Optional:    true,
+			},
+			"config": schema.StringAttribute{
+				Description: "Profile contents",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 hash of the profile",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read downloads the VPN profile of the project, and writes it to the output path, if any.
+func (d *V1ProjectVPNConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state V1ProjectVPNConfigDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	format := V1ProjectVPNConfigFormatOVPN
+	if !state.Format.IsNull() {
+		format = state.Format.ValueString()
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	config, r, err := d.client.ProjectsApi.V1GetProjectVpnConfig(auth, state.Project.ValueString(), format).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project"),
+				"Project not found",
+				"No project was found with the id "+state.Project.ValueString(),
+			)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Unable to read VPN profile", "An unexpected error was encountered trying to download the VPN profile of the project", apiErr)
+		return
+	}
+
+	if !state.OutputPath.IsNull() {
+		output := state.OutputPath.ValueString()
+		if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_path"),
+				"Unable to write VPN profile",
+				"Coudn't create the directory of "+output+": "+err.Error(),
+			)
+			return
+		}
+
+		// NOTICE: The profile has the credentials of the VPN, so only the owner can read it.
+		if err := os.WriteFile(output, []byte(config), 0o600); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_path"),
+				"Unable to write VPN profile",
+				"Coudn't write the VPN profile to "+output+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	state.Id = types.StringValue(state.Project.ValueString())
+	state.Config = types.StringValue(config)
+	state.SHA256 = types.StringValue(contentSHA256([]byte(config)))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *V1ProjectVPNConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
@@ -0,0 +1,57 @@
+package corellium
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"terraform-provider-corellium/corellium/pkg/mock"
+)
+
+func TestAccCorelliumV1ProjectVPNConfigDataSource(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "vpn", "project.ovpn")
+
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttrPair("data.corellium_v1project_vpn_config.test", "id", "corellium_v1project.test", "id"),
+		resource.TestCheckResourceAttrSet("data.corellium_v1project_vpn_config.test", "sha256"),
+	}
+	if testAccMock != nil {
+		checks = append(checks, func(s *terraform.State) error {
+			project := s.RootModule().Resources["corellium_v1project.test"].Primary.ID
+
+			return resource.TestCheckResourceAttr("data.corellium_v1project_vpn_config.test", "sha256", contentSHA256([]byte(mock.VPNConfig(project))))(s)
+		})
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+                resource "corellium_v1project" "test" {
+                    name = "`+t.Name()+`"
+                    settings = {
+                        version = 1
+                        internet_access = true
+                        dhcp = false
+                    }
+                    quotas = {
+                        cores = 2
+                    }
+                    users = []
+                    teams = []
+                    keys  = []
+                }
+
+                data "corellium_v1project_vpn_config" "test" {
+                    project = corellium_v1project.test.id
+                    output_path = %q
+                }
+                `, output),
+				Check: resource.ComposeTestCheckFunc(checks...),
+			},
+		},
+	})
+}
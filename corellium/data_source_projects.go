@@ -2,9 +2,8 @@ package corellium
 
 import (
 	"context"
-	"io"
+	"errors"
 	"math/big"
-	"net/http"
 
 	"github.com/aimoda/go-corellium-api-client"
 	"github.com/hashicorp/go-uuid"
@@ -220,27 +219,13 @@ func (d *V1ProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	projects, r, err := d.client.ProjectsApi.V1GetProjects(auth).Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Error getting projects",
-				"You don't have permission to get the projects",
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error getting projects", "You don't have permission to get the projects", apiErr)
 			return
 		}
 
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error getting projects",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error getting projects",
-			"An unexpected error was encountered trying to get the projects\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error getting projects", "An unexpected error was encountered trying to get the projects", apiErr)
 		return
 	}
 
@@ -277,27 +262,16 @@ func (d *V1ProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 			return
 		}
 
-		projectKeys, r, err := d.client.ProjectsApi.V1GetProjectKeys(auth, project.Id).Execute()
+		projectKeys, r, err := V1GetProjectKeysManual(auth, d.client.GetConfig(), project.Id)
 		if err != nil {
-			b, err := io.ReadAll(r.Body)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error reading the project keys",
-					"Coudn't read the response body: "+err.Error(),
-				)
-				return
-			}
-
-			resp.Diagnostics.AddError(
-				"Unable to read project keys",
-				"An unexpected error was encountered trying to read the project keys from the project:\n\n"+string(b))
+			addAPIError(&resp.Diagnostics, "Unable to read project keys", "An unexpected error was encountered trying to read the project keys from the project", NewAPIError(r, err))
 			return
 		}
 
 		state.Projects[i].Keys = make([]V1ProjectKeyModel, len(projectKeys))
 		for j, key := range projectKeys {
 			state.Projects[i].Keys[j].Id = types.StringValue(key.GetIdentifier())
-			state.Projects[i].Keys[j].Label = types.StringValue("")
+			state.Projects[i].Keys[j].Label = types.StringValue(key.Label)
 			state.Projects[i].Keys[j].Kind = types.StringValue(key.GetKind())
 			state.Projects[i].Keys[j].Key = types.StringValue(key.GetKey())
 			state.Projects[i].Keys[j].Fingerprint = types.StringValue(key.GetFingerprint())
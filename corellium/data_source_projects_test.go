@@ -7,6 +7,7 @@ import (
 )
 
 func TestAccCorelliumV1ProjectsDataSource(t *testing.T) {
+	// NOTICE: The keys of every project are read, so the test doesn't run in parallel with the tests that delete them.
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -2,8 +2,7 @@ package corellium
 
 import (
 	"context"
-	"io"
-	"net/http"
+	"errors"
 
 	"github.com/aimoda/go-corellium-api-client"
 	"github.com/hashicorp/go-uuid"
@@ -119,27 +118,13 @@ func (d *V1RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// auth is the context with the access token, what is required by the API client.
 	roles, r, err := d.client.RolesApi.V1Roles(auth).Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Error gettings roles",
-				"The user doesn't have permission to get the roles.",
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error gettings roles", "The user doesn't have permission to get the roles", apiErr)
 			return
 		}
 
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error gettings roles",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error gettings roles",
-			"An unexpected error was encountered trying to get the roles:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error gettings roles", "An unexpected error was encountered trying to get the roles", apiErr)
 		return
 	}
 
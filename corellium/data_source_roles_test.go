@@ -35,7 +35,7 @@ func testAccCorelliumV1RolesDataSourceConfigWithProject(project string) string {
 }
 
 func TestAccCorelliumV1RolesDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
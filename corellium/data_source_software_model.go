@@ -160,7 +160,7 @@ func (d *V1ModelSoftwareDataSource) Read(ctx context.Context, req datasource.Rea
 	// software, _, err := d.client.ModelsApi.V1GetModelSoftware(auth, state.Model.ValueString()).Execute()
 	// Replace apiUrl with the actual API URL. Workaround for endpoint.
 	url := strings.Join([]string{"https://", os.Getenv("CORELLIUM_API_HOST"), "/api"}, "")
-	customSoftware, err := V1GetModelSoftwareManual(auth, url, state.Model.ValueString())
+	customSoftware, err := V1GetModelSoftwareManual(auth, d.client.GetConfig().HTTPClient, url, state.Model.ValueString())
 	// if err != nil && software != nil {
 	// 	resp.Diagnostics.AddError(
 	// 		"Error getting model software for model: "+state.Model.ValueString()+" Build ID: "+software[0].GetBuildid(),
@@ -256,8 +256,7 @@ type CustomFirmware struct {
 // }
 
 // Workaround for Corellium ModelsAPI. This API is not currently working as expected. (returning values such as Size that are larger than Int32 can handle)
-func V1GetModelSoftwareManual(ctx context.Context, url string, model string) ([]CustomFirmware, error) {
-	client := &http.Client{}
+func V1GetModelSoftwareManual(ctx context.Context, client *http.Client, url string, model string) ([]CustomFirmware, error) {
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url+"/v1/models/"+model+"/software", nil)
 	if err != nil {
@@ -19,7 +19,7 @@ func testAccCorelliumV1SoftwareDataSourceConfig(model string) string {
 }
 
 func TestAccCorelliumV1SofwareDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
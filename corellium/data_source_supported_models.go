@@ -2,7 +2,7 @@ package corellium
 
 import (
 	"context"
-	"net/http"
+	"errors"
 
 	"github.com/aimoda/go-corellium-api-client"
 	"github.com/hashicorp/go-uuid"
@@ -126,17 +126,13 @@ func (d *V1SupportedModelsDataSource) Read(ctx context.Context, req datasource.R
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	models, r, err := d.client.ModelsApi.V1GetModels(auth).Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Error getting supported models",
-				"The user doesn't have permission to get supported models",
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error getting supported models", "The user doesn't have permission to get supported models", apiErr)
 			return
 		}
-		resp.Diagnostics.AddError(
-			"Unable to fetch Corellium Supported Models",
-			err.Error(),
-		)
+
+		addAPIError(&resp.Diagnostics, "Unable to fetch Corellium Supported Models", "An unexpected error was encountered trying to read the supported models", apiErr)
 		return
 	}
 	// Map response body to model
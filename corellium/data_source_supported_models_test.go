@@ -1,9 +1,11 @@
 package corellium
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"terraform-provider-corellium/corellium/pkg/mock"
 )
 
 func testAccCorelliumV1SupportedModelsDataSourceConfig() string {
@@ -11,14 +13,19 @@ func testAccCorelliumV1SupportedModelsDataSourceConfig() string {
 }
 
 func TestAccCorelliumV1SupportedModelsDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	count := "55"
+	if testAccMock != nil {
+		count = strconv.Itoa(len(mock.Models()))
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: providerConfig + testAccCorelliumV1SupportedModelsDataSourceConfig(),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					// Verify number of supported models returned
-					resource.TestCheckResourceAttr("data.corellium_v1supportedmodels.test", "supported_models.#", "55"),
+					resource.TestCheckResourceAttr("data.corellium_v1supportedmodels.test", "supported_models.#", count),
 					// Verify the first model to ensure all attributes are set
 					resource.TestCheckResourceAttr("data.corellium_v1supportedmodels.test", "supported_models.0.type", "ios"),
 					resource.TestCheckResourceAttr("data.corellium_v1supportedmodels.test", "supported_models.0.name", "iphone14pm"),
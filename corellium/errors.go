@@ -0,0 +1,217 @@
+package corellium
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+var (
+	// ErrNotFound is the error kind for resources that don't exist in the API.
+	ErrNotFound = errors.New("not found")
+	// ErrForbidden is the error kind for requests the token doesn't have permission to do.
+	ErrForbidden = errors.New("forbidden")
+	// ErrQuotaExceeded is the error kind for requests that exceed the project, or the account, quotas.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrValidation is the error kind for requests with invalid parameters.
+	ErrValidation = errors.New("validation failed")
+	// ErrConflict is the error kind for requests that conflict with the current state of the resource.
+	ErrConflict = errors.New("conflict")
+	// ErrUnexpected is the error kind for any other error returned by the API.
+	ErrUnexpected = errors.New("unexpected error")
+	// ErrNetwork is the error kind for requests that didn't get a response from the API, e.g. a dropped connection.
+	ErrNetwork = errors.New("network error")
+)
+
+// requestIDHeaders are the response headers that can hold the request ID, in order of preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Correlation-Id"}
+
+// APIError is an error returned by the Corellium API client, decoded from the response.
+// It matches one of the error kinds, e.g. errors.Is(err, ErrNotFound), and the error returned by the API client.
+type APIError struct {
+	// Kind is the error kind, e.g. ErrNotFound.
+	Kind error
+	// StatusCode is the HTTP status code of the response, or zero when there is no response.
+	StatusCode int
+	// RequestID is the ID the API assigned to the request, if any.
+	RequestID string
+	// ErrorID is the error ID returned by the API, if any.
+	ErrorID string
+	// Message is the error message returned by the API, or the response body when it isn't an API error.
+	Message string
+	// Field is the request field the API reported as invalid, if any.
+	Field string
+	// Err is the error returned by the API client.
+	Err error
+}
+
+// apiErrorBody maps the error models returned by the API, e.g. ApiError and ValidationError.
+type apiErrorBody struct {
+	Error         string `json:"error"`
+	ErrorID       string `json:"errorID"`
+	Field         string `json:"field"`
+	OriginalError string `json:"originalError"`
+}
+
+// NewAPIError decodes the response, and the error, returned by the API client into an APIError.
+// The response can be nil, what happens when the request didn't reach the API.
+func NewAPIError(r *http.Response, err error) *APIError {
+	e := &APIError{
+		Kind: ErrUnexpected,
+		Err:  err,
+	}
+
+	if r == nil {
+		e.Kind = ErrNetwork
+		if err != nil {
+			e.Message = err.Error()
+		}
+
+		return e
+	}
+
+	e.StatusCode = r.StatusCode
+	for _, h := range requestIDHeaders {
+		if id := r.Header.Get(h); id != "" {
+			e.RequestID = id
+			break
+		}
+	}
+
+	// NOTICE: The API client replaces the response body with a buffer, so it can be read again here.
+	var b []byte
+	if r.Body != nil {
+		b, _ = io.ReadAll(r.Body)
+	}
+
+	var body apiErrorBody
+	if json.Unmarshal(b, &body) == nil && body.Error != "" {
+		e.ErrorID = body.ErrorID
+		e.Field = body.Field
+		e.Message = body.Error
+		if body.OriginalError != "" {
+			e.Message += ": " + body.OriginalError
+		}
+	} else if len(b) > 0 {
+		e.Message = strings.TrimSpace(string(b))
+	} else if err != nil {
+		e.Message = err.Error()
+	}
+
+	switch {
+	case isQuotaError(r.StatusCode, e.ErrorID, e.Message):
+		e.Kind = ErrQuotaExceeded
+	case r.StatusCode == http.StatusNotFound:
+		e.Kind = ErrNotFound
+	case r.StatusCode == http.StatusForbidden || r.StatusCode == http.StatusUnauthorized:
+		e.Kind = ErrForbidden
+	case r.StatusCode == http.StatusConflict:
+		e.Kind = ErrConflict
+	case r.StatusCode == http.StatusBadRequest || r.StatusCode == http.StatusUnprocessableEntity:
+		e.Kind = ErrValidation
+	}
+
+	return e
+}
+
+// isQuotaError reports whether the response is a quota error. The API doesn't have a quota error model, so it is
+// identified by the status code, or the error ID and message.
+func isQuotaError(statusCode int, errorID, message string) bool {
+	if statusCode == http.StatusPaymentRequired {
+		return true
+	}
+
+	if statusCode < http.StatusBadRequest {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(errorID), "quota") || strings.Contains(strings.ToLower(message), "quota")
+}
+
+// Error returns the error kind, the message and the request ID, if any.
+func (e *APIError) Error() string {
+	var s strings.Builder
+
+	s.WriteString(e.Kind.Error())
+	if e.Message != "" {
+		s.WriteString(": " + e.Message)
+	}
+
+	if e.RequestID != "" {
+		s.WriteString(" (request ID: " + e.RequestID + ")")
+	}
+
+	return s.String()
+}
+
+// Unwrap returns the error kind and the error returned by the API client.
+func (e *APIError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// Detail returns the diagnostic detail for the error, prefixed by the explanation of what was being done.
+func (e *APIError) Detail(prefix string) string {
+	var s strings.Builder
+
+	s.WriteString(prefix + ":\n\n")
+	if e.Message != "" {
+		s.WriteString(e.Message)
+	} else {
+		s.WriteString(e.Kind.Error())
+	}
+
+	if e.RequestID != "" {
+		s.WriteString("\n\nRequest ID: " + e.RequestID)
+	}
+
+	return s.String()
+}
+
+// addAPIError appends the API error to the diagnostics. The detail explains what was being done, e.g. "An unexpected
+// error was encountered trying to create the instance". A validation error for a field is scoped to its attribute.
+func addAPIError(diags *diag.Diagnostics, summary, detail string, err *APIError) {
+	if errors.Is(err, ErrValidation) && err.Field != "" {
+		diags.AddAttributeError(attributePath(err.Field), summary, err.Detail(detail))
+		return
+	}
+
+	diags.AddError(summary, err.Detail(detail))
+}
+
+// attributePath converts an API field, e.g. bootOptions.additionalTags, to the attribute path, e.g.
+// boot_options.additional_tags.
+func attributePath(field string) path.Path {
+	names := strings.Split(field, ".")
+
+	p := path.Root(attributeName(names[0]))
+	for _, name := range names[1:] {
+		p = p.AtName(attributeName(name))
+	}
+
+	return p
+}
+
+// attributeName converts an API field name, e.g. bootOptions, to the attribute name, e.g. boot_options.
+func attributeName(field string) string {
+	var s strings.Builder
+
+	for i, c := range field {
+		if unicode.IsUpper(c) {
+			if i > 0 {
+				s.WriteRune('_')
+			}
+
+			c = unicode.ToLower(c)
+		}
+
+		s.WriteRune(c)
+	}
+
+	return s.String()
+}
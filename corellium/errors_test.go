@@ -0,0 +1,135 @@
+package corellium
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func newTestResponse(statusCode int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	clientErr := errors.New("client error")
+
+	tests := []struct {
+		name    string
+		r       *http.Response
+		kind    error
+		message string
+		field   string
+	}{
+		{
+			name:    "no response",
+			r:       nil,
+			kind:    ErrNetwork,
+			message: "client error",
+		},
+		{
+			name:    "not found",
+			r:       newTestResponse(http.StatusNotFound, `{"error":"Instance not found","errorID":"NotFound"}`, nil),
+			kind:    ErrNotFound,
+			message: "Instance not found",
+		},
+		{
+			name:    "forbidden",
+			r:       newTestResponse(http.StatusForbidden, `{"error":"Forbidden"}`, nil),
+			kind:    ErrForbidden,
+			message: "Forbidden",
+		},
+		{
+			name:    "quota",
+			r:       newTestResponse(http.StatusBadRequest, `{"error":"Project quota exceeded for cores"}`, nil),
+			kind:    ErrQuotaExceeded,
+			message: "Project quota exceeded for cores",
+		},
+		{
+			name:    "validation",
+			r:       newTestResponse(http.StatusBadRequest, `{"error":"Invalid additional tag","field":"bootOptions.additionalTags"}`, nil),
+			kind:    ErrValidation,
+			message: "Invalid additional tag",
+			field:   "bootOptions.additionalTags",
+		},
+		{
+			name:    "conflict",
+			r:       newTestResponse(http.StatusConflict, `{"error":"Instance is busy","originalError":"task running"}`, nil),
+			kind:    ErrConflict,
+			message: "Instance is busy: task running",
+		},
+		{
+			name:    "unexpected body",
+			r:       newTestResponse(http.StatusInternalServerError, "upstream failure\n", nil),
+			kind:    ErrUnexpected,
+			message: "upstream failure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewAPIError(tt.r, clientErr)
+
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("expected kind %q, got %q", tt.kind, err.Kind)
+			}
+
+			if !errors.Is(err, clientErr) {
+				t.Errorf("expected the client error to be wrapped")
+			}
+
+			if err.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, err.Message)
+			}
+
+			if err.Field != tt.field {
+				t.Errorf("expected field %q, got %q", tt.field, err.Field)
+			}
+		})
+	}
+}
+
+func TestAPIErrorDetail(t *testing.T) {
+	err := NewAPIError(newTestResponse(http.StatusNotFound, `{"error":"Instance not found"}`, http.Header{"X-Request-Id": []string{"abc123"}}), errors.New("404 Not Found"))
+
+	if err.RequestID != "abc123" {
+		t.Fatalf("expected request ID %q, got %q", "abc123", err.RequestID)
+	}
+
+	want := "An unexpected error was encountered trying to read the instance:\n\nInstance not found\n\nRequest ID: abc123"
+	if got := err.Detail("An unexpected error was encountered trying to read the instance"); got != want {
+		t.Errorf("expected detail %q, got %q", want, got)
+	}
+}
+
+func TestAddAPIError(t *testing.T) {
+	var diags diag.Diagnostics
+
+	err := NewAPIError(newTestResponse(http.StatusBadRequest, `{"error":"Invalid additional tag","field":"bootOptions.additionalTags"}`, nil), errors.New("400 Bad Request"))
+	addAPIError(&diags, "Error creating instance", "An unexpected error was encountered trying to create the instance", err)
+
+	if len(diags) != 1 {
+		t.Fatalf("expected one diagnostic, got %d", len(diags))
+	}
+
+	d, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok {
+		t.Fatalf("expected an attribute diagnostic")
+	}
+
+	if want := path.Root("boot_options").AtName("additional_tags"); !d.Path().Equal(want) {
+		t.Errorf("expected path %s, got %s", want, d.Path())
+	}
+}
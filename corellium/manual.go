@@ -0,0 +1,70 @@
+package corellium
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// newManualRequest returns a request to the path of the API, e.g. /api/v1/instances, with the access token of the
+// context, for the endpoints the API client doesn't have, or can't decode. A request with a body is sent as JSON.
+func newManualRequest(ctx context.Context, cfg *corellium.Configuration, method, path string, body io.Reader) (*http.Request, error) {
+	scheme := "https"
+	if cfg.Scheme != "" {
+		scheme = cfg.Scheme
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, scheme+"://"+cfg.Host+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get access token from context and add it to the request header
+	accessToken, ok := ctx.Value(corellium.ContextAccessToken).(string)
+	if !ok {
+		return nil, errors.New("access token not found in context")
+	}
+	req.Header.Add("Authorization", "Bearer "+accessToken)
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// sendManualRequest sends the request with the HTTP client of the configuration, and returns the response body. The
+// response body is replaced with a buffer, like the API client does, so NewAPIError can read it on error.
+func sendManualRequest(cfg *corellium.Configuration, req *http.Request) ([]byte, *http.Response, error) {
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, resp, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp, err
+	}
+	resp.Body = io.NopCloser(bytes.NewBuffer(b))
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, resp, fmt.Errorf("error sending %s %s: %s", req.Method, req.URL.Path, resp.Status)
+	}
+
+	return b, resp, nil
+}
+
+// doManualRequest sends a request to the path of the API with the body, and returns the response body.
+func doManualRequest(ctx context.Context, cfg *corellium.Configuration, method, path string, body io.Reader) ([]byte, *http.Response, error) {
+	req, err := newManualRequest(ctx, cfg, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sendManualRequest(cfg, req)
+}
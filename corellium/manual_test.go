@@ -0,0 +1,96 @@
+package corellium
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+func newTestManualConfiguration(t *testing.T, handler http.HandlerFunc) *corellium.Configuration {
+	t.Helper()
+
+	s := httptest.NewServer(handler)
+	t.Cleanup(s.Close)
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatalf("unexpected error parsing the server URL: %s", err)
+	}
+
+	cfg := corellium.NewConfiguration()
+	cfg.Scheme = u.Scheme
+	cfg.Host = u.Host
+	cfg.HTTPClient = s.Client()
+
+	return cfg
+}
+
+func TestDoManualRequest(t *testing.T) {
+	cfg := newTestManualConfiguration(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("expected the access token, got %q", got)
+		}
+
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/instances/id/peripherals" {
+			t.Errorf("expected PUT /api/v1/instances/id/peripherals, got %s %s", r.Method, r.URL.Path)
+		}
+
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("expected a JSON body, got %q", got)
+		}
+
+		b, _ := io.ReadAll(r.Body)
+		w.Write(b)
+	})
+
+	ctx := context.WithValue(context.Background(), corellium.ContextAccessToken, "token")
+
+	b, _, err := doManualRequest(ctx, cfg, http.MethodPut, "/api/v1/instances/id/peripherals", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(b) != `{}` {
+		t.Errorf("expected the response body, got %q", b)
+	}
+}
+
+func TestDoManualRequest_error(t *testing.T) {
+	cfg := newTestManualConfiguration(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "" {
+			t.Errorf("expected no content type without a body, got %q", got)
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Instance not found"}`))
+	})
+
+	ctx := context.WithValue(context.Background(), corellium.ContextAccessToken, "token")
+
+	_, r, err := doManualRequest(ctx, cfg, http.MethodGet, "/api/v1/instances/id/strace", nil)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	apiErr := NewAPIError(r, err)
+	if !errors.Is(apiErr, ErrNotFound) || apiErr.Message != "Instance not found" {
+		t.Errorf("expected a not found error with the response message, got %v", apiErr)
+	}
+}
+
+func TestDoManualRequest_noAccessToken(t *testing.T) {
+	cfg := newTestManualConfiguration(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected no request without an access token")
+	})
+
+	if _, _, err := doManualRequest(context.Background(), cfg, http.MethodGet, "/api/v1/instances", nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,250 @@
+package mock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// agentBootChecks is the number of times the agent is checked, after the instance is on, before it is ready, like
+// the agent that starts after the device boots.
+const agentBootChecks = 1
+
+// agent returns the instance of the request path when its agent is reachable, i.e. the instance is on, writing an
+// error otherwise.
+func (s *Server) agent(w http.ResponseWriter, r *http.Request) (*instance, bool) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	if i.GetState() != corellium.ON {
+		writeError(w, http.StatusConflict, "Conflict", "The agent isn't available while the instance is "+string(i.GetState()))
+		return nil, false
+	}
+
+	return i, true
+}
+
+// agentAppReady handles GET /v1/instances/{instanceId}/agent/v1/app/ready. The agent isn't ready the first times it
+// is checked after the instance is on.
+func (s *Server) agentAppReady(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.agent(w, r)
+	if !ok {
+		return
+	}
+
+	if i.agentPending > 0 {
+		i.agentPending--
+		writeJSON(w, http.StatusOK, corellium.NewAgentAppReadyResponse(false))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, corellium.NewAgentAppReadyResponse(true))
+}
+
+// agentGetProp handles POST /v1/instances/{instanceId}/agent/v1/system/getprop, what is only available on Android.
+// The boot is completed once the agent is ready.
+func (s *Server) agentGetProp(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.agent(w, r)
+	if !ok {
+		return
+	}
+
+	if i.GetType() != "android" {
+		writeError(w, http.StatusBadRequest, "BadRequest", "System properties are only available on Android")
+		return
+	}
+
+	var body corellium.AgentSystemGetPropBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	props := map[string]string{
+		"ro.build.version.release": i.GetOs(),
+		"ro.product.model":         i.GetFlavor(),
+		"sys.boot_completed":       "0",
+	}
+	if i.agentPending == 0 {
+		props["sys.boot_completed"] = "1"
+	}
+
+	v := corellium.NewAgentValueReturn()
+	if value, ok := props[body.GetProperty()]; ok {
+		v.SetValue(value)
+	}
+
+	writeJSON(w, http.StatusOK, v)
+}
+
+// agentTempFilename handles POST /v1/instances/{instanceId}/agent/v1/file/temp.
+func (s *Server) agentTempFilename(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.agent(w, r); !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, "/tmp/"+newID())
+}
+
+// agentGetFile handles GET /v1/instances/{instanceId}/agent/v1/file/device/{filePath}.
+func (s *Server) agentGetFile(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.agent(w, r)
+	if !ok {
+		return
+	}
+
+	b, ok := i.files[r.PathValue("filePath")]
+	if !ok {
+		writeNotFound(w, "File")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
+
+// agentUploadFile handles PUT /v1/instances/{instanceId}/agent/v1/file/device/{filePath}.
+func (s *Server) agentUploadFile(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.agent(w, r)
+	if !ok {
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", "Couldn't read the file: "+err.Error())
+		return
+	}
+
+	if i.files == nil {
+		i.files = map[string][]byte{}
+	}
+	i.files[r.PathValue("filePath")] = b
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// agentDeleteFile handles DELETE /v1/instances/{instanceId}/agent/v1/file/device/{filePath}.
+func (s *Server) agentDeleteFile(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.agent(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := i.files[r.PathValue("filePath")]; !ok {
+		writeNotFound(w, "File")
+		return
+	}
+
+	delete(i.files, r.PathValue("filePath"))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AppBundleID returns the bundle ID the server gives to the app installed from the package contents, what is derived
+// from its hash, so the same package is the same app.
+func AppBundleID(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return "com.corellium.mock." + hex.EncodeToString(sum[:4])
+}
+
+// agentInstallApp handles POST /v1/instances/{instanceId}/agent/v1/app/install. The app is installed from a file
+// uploaded to the device.
+func (s *Server) agentInstallApp(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.agent(w, r)
+	if !ok {
+		return
+	}
+
+	var body corellium.AgentInstallBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	b, ok := i.files[body.GetPath()]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "BadRequest", "No such file "+body.GetPath())
+		return
+	}
+
+	app := corellium.NewAgentApp()
+	app.SetBundleID(AppBundleID(b))
+	app.SetName("Mock App")
+	app.SetApplicationType("User")
+	app.SetRunning(false)
+	app.SetDiskUsage(int32(len(b)))
+
+	if i.apps == nil {
+		i.apps = map[string]*corellium.AgentApp{}
+	}
+	i.apps[app.GetBundleID()] = app
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// agentListApps handles GET /v1/instances/{instanceId}/agent/v1/app/apps.
+func (s *Server) agentListApps(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.agent(w, r)
+	if !ok {
+		return
+	}
+
+	apps := make([]corellium.AgentApp, 0, len(i.apps))
+	for _, app := range i.apps {
+		apps = append(apps, *app)
+	}
+
+	sort.SliceStable(apps, func(a, b int) bool {
+		return apps[a].GetBundleID() < apps[b].GetBundleID()
+	})
+
+	list := corellium.NewAgentAppsList()
+	list.SetApps(apps)
+	list.SetFrontmost("")
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// agentRunApp returns the handler of POST /v1/instances/{instanceId}/agent/v1/app/apps/{bundleId}/{run|kill}, where
+// running tells if the app is run or killed.
+func (s *Server) agentRunApp(running bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		i, ok := s.agent(w, r)
+		if !ok {
+			return
+		}
+
+		app, ok := i.apps[r.PathValue("bundleId")]
+		if !ok {
+			writeNotFound(w, "App")
+			return
+		}
+
+		app.SetRunning(running)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}
+}
+
+// agentUninstallApp handles POST /v1/instances/{instanceId}/agent/v1/app/apps/{bundleId}/uninstall.
+func (s *Server) agentUninstallApp(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.agent(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := i.apps[r.PathValue("bundleId")]; !ok {
+		writeNotFound(w, "App")
+		return
+	}
+
+	delete(i.apps, r.PathValue("bundleId"))
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
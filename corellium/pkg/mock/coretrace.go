@@ -0,0 +1,118 @@
+package mock
+
+import (
+	"net/http"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// CoreTraceLog is the log the server returns for an instance once CoreTrace was started on it.
+var CoreTraceLog = []byte("[1] 1:launchd open(\"/etc/hosts\", 0x0) = 3\n")
+
+// CoreTraceFilter is a value the threads traced by CoreTrace are filtered by.
+type CoreTraceFilter struct {
+	// Trait is what the value is matched against: pid, name or tid.
+	Trait string `json:"trait"`
+	Value string `json:"value"`
+}
+
+// CoreTrace returns whether CoreTrace is started on the instance, and its filter.
+func (s *Server) CoreTrace(instanceId string) (bool, []CoreTraceFilter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := s.instances[instanceId]
+	if !ok {
+		return false, nil
+	}
+
+	return i.coretrace, append([]CoreTraceFilter{}, i.coretraceFilter...)
+}
+
+// startCoreTrace handles POST /v1/instances/{instanceId}/strace/enable.
+func (s *Server) startCoreTrace(w http.ResponseWriter, r *http.Request) {
+	s.setCoreTrace(w, r, true)
+}
+
+// stopCoreTrace handles POST /v1/instances/{instanceId}/strace/disable.
+func (s *Server) stopCoreTrace(w http.ResponseWriter, r *http.Request) {
+	s.setCoreTrace(w, r, false)
+}
+
+// setCoreTrace starts or stops CoreTrace on the instance.
+func (s *Server) setCoreTrace(w http.ResponseWriter, r *http.Request, started bool) {
+	i, ok := s.coreTraceInstance(w, r)
+	if !ok {
+		return
+	}
+
+	i.coretrace = started
+	if started {
+		i.coretraceLog = CoreTraceLog
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setCoreTraceFilter handles PUT /v1/instances/{instanceId}/strace.
+func (s *Server) setCoreTraceFilter(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.coreTraceInstance(w, r)
+	if !ok {
+		return
+	}
+
+	var body []CoreTraceFilter
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	for _, f := range body {
+		if f.Trait != "pid" && f.Trait != "name" && f.Trait != "tid" {
+			writeValidationError(w, "trait", "Invalid trait "+f.Trait)
+			return
+		}
+	}
+
+	i.coretraceFilter = body
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// coreTraceLog handles GET /v1/instances/{instanceId}/strace.
+func (s *Server) coreTraceLog(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write(i.coretraceLog)
+}
+
+// clearCoreTraceLog handles DELETE /v1/instances/{instanceId}/strace.
+func (s *Server) clearCoreTraceLog(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	i.coretraceLog = nil
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// coreTraceInstance returns the instance of the request, what must be on to configure CoreTrace.
+func (s *Server) coreTraceInstance(w http.ResponseWriter, r *http.Request) (*instance, bool) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	if i.GetState() != corellium.ON {
+		writeError(w, http.StatusConflict, "Conflict", "CoreTrace can't be configured while the instance is "+string(i.GetState()))
+		return nil, false
+	}
+
+	return i, true
+}
@@ -0,0 +1,217 @@
+package mock
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// hookParameters is the request body to create or update a hook. It isn't corellium.V1CreateHookParameters because
+// the provider sends the enabled field the API client doesn't have.
+type hookParameters struct {
+	Label     string `json:"label"`
+	Address   string `json:"address"`
+	Patch     string `json:"patch"`
+	PatchType string `json:"patchType"`
+	Enabled   *bool  `json:"enabled"`
+}
+
+// ExecutedHooks returns the hooks executed on the instance, as they were when they were executed, sorted by label.
+func (s *Server) ExecutedHooks(instanceId string) []corellium.Hook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hooks := []corellium.Hook{}
+	if i, ok := s.instances[instanceId]; ok {
+		for _, h := range i.executed {
+			hooks = append(hooks, h)
+		}
+	}
+	sort.Slice(hooks, func(a, b int) bool {
+		return hooks[a].GetLabel() < hooks[b].GetLabel()
+	})
+
+	return hooks
+}
+
+// listHooks handles GET /v1/instances/{instanceId}/hooks.
+func (s *Server) listHooks(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	hooks := []corellium.Hook{}
+	for _, h := range i.hooks {
+		hooks = append(hooks, *h)
+	}
+	sort.Slice(hooks, func(a, b int) bool {
+		return hooks[a].GetCreatedAt() < hooks[b].GetCreatedAt()
+	})
+
+	writeJSON(w, http.StatusOK, hooks)
+}
+
+// createHook handles POST /v1/instances/{instanceId}/hooks.
+func (s *Server) createHook(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	var body hookParameters
+	if !readJSON(w, r, &body) || !validHook(w, body) {
+		return
+	}
+
+	now := time.Now().UTC().Format(expirationFormat)
+	h := corellium.NewHook()
+	h.SetIdentifier(newID())
+	h.SetInstanceId(i.GetId())
+	h.SetCreatedAt(now)
+	setHook(h, body, now)
+
+	if i.hooks == nil {
+		i.hooks = map[string]*corellium.Hook{}
+	}
+	i.hooks[h.GetIdentifier()] = h
+
+	writeJSON(w, http.StatusOK, h)
+}
+
+// getHook handles GET /v1/hooks/{hookId}.
+func (s *Server) getHook(w http.ResponseWriter, r *http.Request) {
+	_, h, ok := s.hook(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h)
+}
+
+// updateHook handles PUT /v1/hooks/{hookId}. The changes are applied once the hooks are executed again.
+func (s *Server) updateHook(w http.ResponseWriter, r *http.Request) {
+	_, h, ok := s.hook(w, r)
+	if !ok {
+		return
+	}
+
+	var body hookParameters
+	if !readJSON(w, r, &body) || !validHook(w, body) {
+		return
+	}
+
+	setHook(h, body, time.Now().UTC().Format(expirationFormat))
+
+	writeJSON(w, http.StatusOK, h)
+}
+
+// deleteHook handles DELETE /v1/hooks/{hookId}. A deleted hook stays executed until the hooks are cleared.
+func (s *Server) deleteHook(w http.ResponseWriter, r *http.Request) {
+	i, h, ok := s.hook(w, r)
+	if !ok {
+		return
+	}
+
+	delete(i.hooks, h.GetIdentifier())
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// executeHooks handles POST /v1/instances/{instanceId}/hooks/execute.
+func (s *Server) executeHooks(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.hooksInstance(w, r)
+	if !ok {
+		return
+	}
+
+	i.executeHooks()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// clearHooks handles POST /v1/instances/{instanceId}/hooks/clear.
+func (s *Server) clearHooks(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.hooksInstance(w, r)
+	if !ok {
+		return
+	}
+
+	i.clearHooks()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// executeHooks executes the enabled hooks of the instance, what happens when it boots too.
+func (i *instance) executeHooks() {
+	if i.executed == nil {
+		i.executed = map[string]corellium.Hook{}
+	}
+
+	for id, h := range i.hooks {
+		if h.GetEnabled() {
+			i.executed[id] = *h
+		}
+	}
+}
+
+// clearHooks clears the executed hooks of the instance.
+func (i *instance) clearHooks() {
+	i.executed = nil
+}
+
+// hook returns the hook of the request, and its instance, writing a not found error when it doesn't exist.
+func (s *Server) hook(w http.ResponseWriter, r *http.Request) (*instance, *corellium.Hook, bool) {
+	id := r.PathValue("hookId")
+	for _, i := range s.instances {
+		if h, ok := i.hooks[id]; ok {
+			return i, h, true
+		}
+	}
+
+	writeNotFound(w, "Hook")
+	return nil, nil, false
+}
+
+// hooksInstance returns the instance of the request, what must be on to execute or clear its hooks.
+func (s *Server) hooksInstance(w http.ResponseWriter, r *http.Request) (*instance, bool) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	if i.GetState() != corellium.ON {
+		writeError(w, http.StatusConflict, "Conflict", "The hooks can't be executed while the instance is "+string(i.GetState()))
+		return nil, false
+	}
+
+	return i, true
+}
+
+// validHook checks the fields of the hook, writing a validation error when one isn't valid.
+func validHook(w http.ResponseWriter, body hookParameters) bool {
+	switch {
+	case body.Label == "":
+		writeValidationError(w, "label", "Label is required")
+	case body.Address == "":
+		writeValidationError(w, "address", "Address is required")
+	case body.PatchType != "csmfcc" && body.PatchType != "csmfvm":
+		writeValidationError(w, "patchType", "Invalid patch type "+body.PatchType)
+	default:
+		return true
+	}
+
+	return false
+}
+
+// setHook sets the fields of the hook. A hook is enabled unless the body disables it.
+func setHook(h *corellium.Hook, body hookParameters, updatedAt string) {
+	h.SetLabel(body.Label)
+	h.SetAddress(body.Address)
+	h.SetPatch(body.Patch)
+	h.SetPatchType(body.PatchType)
+	h.SetEnabled(body.Enabled == nil || *body.Enabled)
+	h.SetUpdatedAt(updatedAt)
+}
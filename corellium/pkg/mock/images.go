@@ -0,0 +1,210 @@
+package mock
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// maxImageSize is the maximum size of an uploaded image kept in memory, the rest is stored in temporary files.
+const maxImageSize = 32 << 20
+
+// imageTypes are the types of image the API accepts.
+var imageTypes = []string{
+	"fwbinary", "kernel", "devicetree", "ramdisk", "loaderfile", "sepfw", "seprom", "bootrom", "llb", "ibss", "ibec",
+	"fwpackage", "partition", "backup",
+}
+
+// image is an image and how its data is encoded.
+type image struct {
+	corellium.Image
+
+	// encoding is how the data of the image is uploaded, e.g. gzip, what is stored decompressed.
+	encoding string
+}
+
+// FailImageUploads makes the next uploads of the data of an image fail with a 503, once their body is read, as if the
+// connection dropped.
+func (s *Server) FailImageUploads(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failImageUploads = n
+}
+
+// Images returns the images of the project, sorted by creation date.
+func (s *Server) Images(projectId string) []corellium.Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.projectImages(projectId)
+}
+
+// projectImages returns the images of the project, sorted by creation date.
+func (s *Server) projectImages(projectId string) []corellium.Image {
+	images := []corellium.Image{}
+	for _, i := range s.images {
+		if i.GetProject() == projectId {
+			images = append(images, i.Image)
+		}
+	}
+	sort.Slice(images, func(a, b int) bool {
+		return images[a].GetCreatedAt().Before(images[b].GetCreatedAt())
+	})
+
+	return images
+}
+
+// createImage handles POST /v1/images, a multipart form with the image metadata and, optionally, its file. An image
+// created without a file is pending until its data is uploaded.
+func (s *Server) createImage(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(maxImageSize); err != nil {
+		writeValidationError(w, "", "Invalid multipart form: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	for _, field := range []string{"type", "encoding", "name"} {
+		if r.FormValue(field) == "" {
+			writeValidationError(w, field, "The "+field+" field is required")
+			return
+		}
+	}
+
+	if !slices.Contains(imageTypes, r.FormValue("type")) {
+		writeValidationError(w, "type", "Invalid type "+r.FormValue("type"))
+		return
+	}
+
+	encoding := r.FormValue("encoding")
+	if encoding != "plain" && encoding != "encrypted" && encoding != "gzip" {
+		writeValidationError(w, "encoding", "Invalid encoding "+encoding)
+		return
+	}
+
+	project := r.FormValue("project")
+	if project != "" {
+		if _, ok := s.projects[project]; !ok {
+			writeNotFound(w, "Project")
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+
+	i := &image{Image: *corellium.NewImage("pending"), encoding: encoding}
+	i.SetId(newID())
+	i.SetName(r.FormValue("name"))
+	i.SetType(r.FormValue("type"))
+	i.SetProject(project)
+	i.SetCreatedAt(now)
+	i.SetUpdatedAt(now)
+
+	file, header, err := r.FormFile("file")
+	switch {
+	case errors.Is(err, http.ErrMissingFile):
+	case err != nil:
+		writeValidationError(w, "file", "Invalid file: "+err.Error())
+		return
+	default:
+		defer file.Close()
+
+		i.SetFilename(header.Filename)
+		if !s.storeImageData(w, i, file) {
+			return
+		}
+	}
+
+	s.images[i.GetId()] = i
+
+	writeJSON(w, http.StatusOK, i.Image)
+}
+
+// listImages handles GET /v1/images, filtered by the project query parameter.
+func (s *Server) listImages(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.projectImages(r.URL.Query().Get("project")))
+}
+
+// getImage handles GET /v1/images/{imageId}.
+func (s *Server) getImage(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.images[r.PathValue("imageId")]
+	if !ok {
+		writeNotFound(w, "Image")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, i.Image)
+}
+
+// uploadImageData handles POST /v1/images/{imageId}, the data of the image as the request body, what replaces the data
+// the image had.
+func (s *Server) uploadImageData(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.images[r.PathValue("imageId")]
+	if !ok {
+		writeNotFound(w, "Image")
+		return
+	}
+
+	if s.failImageUploads > 0 {
+		s.failImageUploads--
+
+		_, _ = io.Copy(io.Discard, r.Body)
+		writeError(w, http.StatusServiceUnavailable, "ServiceUnavailable", "The upload was interrupted")
+		return
+	}
+
+	if !s.storeImageData(w, i, r.Body) {
+		return
+	}
+	i.SetUpdatedAt(time.Now().UTC())
+
+	writeJSON(w, http.StatusOK, i.Image)
+}
+
+// storeImageData reads the data of the image, decompressing it when it is encoded with gzip, and sets the size and
+// the unique ID of the image from it, what makes the image active.
+func (s *Server) storeImageData(w http.ResponseWriter, i *image, data io.Reader) bool {
+	if i.encoding == "gzip" {
+		gz, err := gzip.NewReader(data)
+		if err != nil {
+			writeValidationError(w, "file", "Invalid gzip file: "+err.Error())
+			return false
+		}
+		defer gz.Close()
+
+		data = gz
+	}
+
+	h := sha256.New()
+	size, err := io.Copy(h, data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalServerError", "Couldn't read the file: "+err.Error())
+		return false
+	}
+
+	i.SetStatus("active")
+	i.SetUniqueid(hex.EncodeToString(h.Sum(nil)))
+	i.SetSize(float32(size))
+
+	return true
+}
+
+// deleteImage handles DELETE /v2/images/{imageId}.
+func (s *Server) deleteImage(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.images[r.PathValue("imageId")]; !ok {
+		writeNotFound(w, "Image")
+		return
+	}
+
+	delete(s.images, r.PathValue("imageId"))
+
+	w.WriteHeader(http.StatusNoContent)
+}
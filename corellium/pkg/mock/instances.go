@@ -0,0 +1,449 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// firstRouterPort is the first port of the project router given to a proxied port without one.
+const firstRouterPort = 10000
+
+// stateGone is the pending state of an instance that is deleted once it is reached.
+const stateGone corellium.InstanceState = ""
+
+// instance is an instance and the states it goes through, one on each time it is read, until its task is done.
+type instance struct {
+	corellium.Instance
+
+	// cores is the number of cores of the instance flavor, used by the project quota.
+	cores float32
+	// pending are the next states of the instance. stateGone deletes the instance.
+	pending []corellium.InstanceState
+	// agentPending is the number of times the agent is checked, after the instance is on, before it is ready.
+	agentPending int
+	// files are the contents of the files uploaded to the device, by path.
+	files map[string][]byte
+	// apps are the apps installed through the agent, by bundle ID.
+	apps map[string]*corellium.AgentApp
+	// captured is true once the network monitor was enabled, so there is a capture to download.
+	captured bool
+	// peripherals are the sensor, GPS and battery values set on the instance, by the name of their field in the API.
+	// They are reset when the instance isn't on anymore.
+	peripherals map[string]interface{}
+	// hooks are the hypervisor hooks of the instance, by ID.
+	hooks map[string]*corellium.Hook
+	// executed are the hooks executed on the instance, as they were when they were executed, by ID. The enabled hooks
+	// are executed when the instance boots, and cleared when it isn't on anymore.
+	executed map[string]corellium.Hook
+	// coretrace is true while CoreTrace is started, what stops when the instance isn't on anymore.
+	coretrace bool
+	// coretraceFilter is the filter of the threads CoreTrace traces.
+	coretraceFilter []CoreTraceFilter
+	// coretraceLog is the CoreTrace log, kept until it is cleared.
+	coretraceLog []byte
+}
+
+// transition changes the instance to the first state, and queues the next ones.
+func (i *instance) transition(states ...corellium.InstanceState) {
+	i.setState(states[0])
+	i.pending = states[1:]
+}
+
+// setState changes the instance state, and the time it changed.
+func (i *instance) setState(state corellium.InstanceState) {
+	i.SetState(state)
+	i.SetStateChanged(time.Now().UTC())
+
+	if state == corellium.ON {
+		// NOTICE: The instance is started again each time it boots, so the services that stop with it can tell it
+		// restarted.
+		i.SetStartedAt(time.Now().UTC().Format(time.RFC3339Nano))
+		i.agentPending = agentBootChecks
+		i.executeHooks()
+	} else {
+		i.peripherals = nil
+		i.clearHooks()
+		i.coretrace = false
+	}
+}
+
+// RestartInstance restarts the instance at once, as if it rebooted outside of Terraform.
+func (s *Server) RestartInstance(instanceId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i, ok := s.instances[instanceId]; ok {
+		i.setState(corellium.REBOOTING)
+		i.transition(corellium.ON)
+	}
+}
+
+// instanceCreateOptions is the request body to create an instance. It isn't corellium.InstanceCreateOptions because the
+// provider can send boot options the API client doesn't have, e.g. a custom kernel.
+type instanceCreateOptions struct {
+	Name        string                         `json:"name"`
+	Flavor      string                         `json:"flavor"`
+	Project     string                         `json:"project"`
+	Os          string                         `json:"os"`
+	Osbuild     string                         `json:"osbuild"`
+	Patches     []string                       `json:"patches"`
+	Fwpackage   string                         `json:"fwpackage"`
+	Snapshot    string                         `json:"snapshot"`
+	BootOptions *corellium.InstanceBootOptions `json:"bootOptions"`
+}
+
+// listInstances handles GET /v1/instances.
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+
+	instances := []corellium.Instance{}
+	for _, i := range s.sortedInstances() {
+		if name != "" && i.GetName() != name {
+			continue
+		}
+
+		instances = append(instances, i.Instance)
+	}
+
+	writeJSON(w, http.StatusOK, instances)
+}
+
+// createInstance handles POST /v1/instances.
+func (s *Server) createInstance(w http.ResponseWriter, r *http.Request) {
+	var opts instanceCreateOptions
+	if !readJSON(w, r, &opts) {
+		return
+	}
+
+	m := findModel(opts.Flavor)
+	if m == nil {
+		writeValidationError(w, "flavor", "Invalid flavor "+opts.Flavor)
+		return
+	}
+
+	if opts.Os == "" || findFirmware(m.GetModel(), opts.Os) == nil {
+		writeValidationError(w, "os", "Invalid os "+opts.Os+" for flavor "+opts.Flavor)
+		return
+	}
+
+	p, ok := s.projects[opts.Project]
+	if !ok {
+		writeNotFound(w, "Project")
+		return
+	}
+
+	cores := flavorCores(opts.Flavor)
+	usage := s.projectUsage(p.GetId())
+	if q := p.GetQuotas(); q.GetCores() > 0 && usage.GetCores()+cores > q.GetCores() {
+		writeError(w, http.StatusForbidden, "QuotaExceeded", "Project quota exceeded for cores")
+		return
+	}
+
+	if opts.Name == "" {
+		opts.Name = m.GetName()
+	}
+
+	if opts.Patches == nil {
+		opts.Patches = []string{}
+	}
+
+	i := &instance{Instance: *corellium.NewInstance(), cores: cores}
+	i.SetId(newID())
+	i.SetName(opts.Name)
+	i.SetKey(opts.Name)
+	i.SetFlavor(opts.Flavor)
+	i.SetType(m.GetType())
+	i.SetProject(p.GetId())
+	i.SetModel(m.GetModel())
+	i.SetOs(opts.Os)
+	i.SetFwpackage(opts.Fwpackage)
+	i.SetPatches(opts.Patches)
+	i.SetCreated(time.Now().UTC())
+	i.SetUserTask("")
+	i.SetTaskState("")
+	i.SetError("")
+	i.SetPanicked(false)
+	i.SetFault(false)
+	i.SetServiceIp("10.11.0.1")
+	i.SetWifiIp("10.11.1.1")
+	i.SetSecondaryIp("10.11.3.1")
+	i.SetServices(corellium.InstanceServices{
+		Vpn: &corellium.VpnDefinition{
+			// NOTICE: The VPN ports are proxy configurations, e.g. the SSH port of the device on the project router.
+			Proxy: []map[string]interface{}{
+				{"devicePort": 22, "routerPort": 22, "expose": false, "firstAvailable": true},
+			},
+			Listeners: []map[string]interface{}{},
+		},
+	})
+	i.SetExposePort("")
+	i.SetAgentNil()
+	i.SetNetmon(corellium.InstanceNetmonState{})
+
+	bootOptions := corellium.NewInstanceBootOptions()
+	if opts.BootOptions != nil {
+		bootOptions = opts.BootOptions
+	}
+
+	if !bootOptions.HasBootArgs() {
+		bootOptions.SetBootArgs("")
+	}
+
+	if !bootOptions.HasUdid() {
+		bootOptions.SetUdid(newID())
+	}
+
+	if !bootOptions.HasEcid() {
+		bootOptions.SetEcid(strings.ReplaceAll(newID(), "-", "")[:14])
+	}
+
+	i.SetBootOptions(*bootOptions)
+	i.SetServices(corellium.InstanceServices{Vpn: &corellium.VpnDefinition{}})
+
+	i.transition(corellium.CREATING, corellium.BOOTING, corellium.ON)
+	s.instances[i.GetId()] = i
+
+	// NOTICE: The API takes a fresh snapshot of every new instance, so it can be restored to its initial state.
+	s.addSnapshot(i.GetId(), "fresh", true)
+
+	writeJSON(w, http.StatusOK, corellium.NewInstanceReturn(i.GetId(), i.GetState()))
+}
+
+// getInstance handles GET /v1/instances/{instanceId}. It responds with the current state of the instance, and then
+// moves it to the next pending state.
+func (s *Server) getInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, i.Instance)
+
+	if len(i.pending) == 0 {
+		return
+	}
+
+	next := i.pending[0]
+	i.pending = i.pending[1:]
+	if next == stateGone {
+		s.removeInstance(i.GetId())
+		return
+	}
+
+	i.setState(next)
+}
+
+// patchInstance handles PATCH /v1/instances/{instanceId}.
+func (s *Server) patchInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	var opts corellium.PatchInstanceOptions
+	if !readJSON(w, r, &opts) {
+		return
+	}
+
+	if opts.HasName() {
+		i.SetName(opts.GetName())
+	}
+
+	if opts.BootOptions != nil {
+		bootOptions := i.GetBootOptions()
+		b, _ := json.Marshal(opts.BootOptions)
+		_ = json.Unmarshal(b, &bootOptions)
+		i.SetBootOptions(bootOptions)
+	}
+
+	if opts.Proxy != nil {
+		services := i.GetServices()
+		vpn := services.GetVpn()
+
+		// NOTICE: A port without a router port is given the first available one, like the API does.
+		used := map[float32]bool{}
+		for _, p := range opts.Proxy {
+			if p.RouterPort.Get() != nil {
+				used[p.GetRouterPort()] = true
+			}
+		}
+
+		vpn.Proxy = make([]map[string]interface{}, 0, len(opts.Proxy))
+		for _, p := range opts.Proxy {
+			if p.RouterPort.Get() == nil {
+				port := float32(firstRouterPort)
+				for used[port] {
+					port++
+				}
+
+				used[port] = true
+				p.SetRouterPort(port)
+			}
+
+			var m map[string]interface{}
+			b, _ := json.Marshal(p)
+			_ = json.Unmarshal(b, &m)
+			vpn.Proxy = append(vpn.Proxy, m)
+		}
+
+		services.SetVpn(vpn)
+		i.SetServices(services)
+	}
+
+	switch corellium.InstanceState(opts.GetState()) {
+	case corellium.ON:
+		i.transition(corellium.BOOTING, corellium.ON)
+	case corellium.OFF:
+		i.transition(corellium.OFF)
+	case corellium.PAUSED:
+		i.transition(corellium.PAUSED)
+	}
+
+	writeJSON(w, http.StatusOK, i.Instance)
+}
+
+// deleteInstance handles DELETE /v1/instances/{instanceId}.
+func (s *Server) deleteInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	i.transition(corellium.DELETING, stateGone)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// startInstance handles POST /v1/instances/{instanceId}/start.
+func (s *Server) startInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	var opts corellium.InstanceStartOptions
+	if r.ContentLength != 0 && !readJSON(w, r, &opts) {
+		return
+	}
+
+	if opts.GetPaused() {
+		i.transition(corellium.BOOTING, corellium.PAUSED)
+	} else {
+		i.transition(corellium.BOOTING, corellium.ON)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// stopInstance handles POST /v1/instances/{instanceId}/stop.
+func (s *Server) stopInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	i.transition(corellium.OFF)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pauseInstance handles POST /v1/instances/{instanceId}/pause.
+func (s *Server) pauseInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	i.transition(corellium.PAUSED)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// unpauseInstance handles POST /v1/instances/{instanceId}/unpause.
+func (s *Server) unpauseInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	i.transition(corellium.ON)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// rebootInstance handles POST /v1/instances/{instanceId}/reboot.
+func (s *Server) rebootInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	i.transition(corellium.REBOOTING, corellium.ON)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// upgradeInstance handles POST /v1/instances/{instanceId}/upgrade.
+func (s *Server) upgradeInstance(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	var opts corellium.InstanceUpgradeBody
+	if !readJSON(w, r, &opts) {
+		return
+	}
+
+	if opts.GetOs() == "" || findFirmware(i.GetModel(), opts.GetOs()) == nil {
+		writeValidationError(w, "os", "Invalid os "+opts.GetOs()+" for flavor "+i.GetFlavor())
+		return
+	}
+
+	i.SetOs(opts.GetOs())
+
+	i.transition(corellium.RESTORING, corellium.ON)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// instance returns the instance of the request path, writing a not found error when it doesn't exist.
+func (s *Server) instance(w http.ResponseWriter, r *http.Request) (*instance, bool) {
+	i, ok := s.instances[r.PathValue("instanceId")]
+	if !ok {
+		writeNotFound(w, "Instance")
+		return nil, false
+	}
+
+	return i, true
+}
+
+// removeInstance removes the instance and its snapshots.
+func (s *Server) removeInstance(id string) {
+	delete(s.instances, id)
+
+	for sid, snap := range s.snapshots {
+		if snap.GetInstance() == id {
+			delete(s.snapshots, sid)
+		}
+	}
+}
+
+// sortedInstances returns the instances by creation date.
+func (s *Server) sortedInstances() []*instance {
+	instances := make([]*instance, 0, len(s.instances))
+	for _, i := range s.instances {
+		instances = append(instances, i)
+	}
+
+	sort.SliceStable(instances, func(a, b int) bool {
+		return instances[a].GetCreated().Before(instances[b].GetCreated())
+	})
+
+	return instances
+}
@@ -0,0 +1,173 @@
+package mock
+
+import (
+	"net/http"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// firmware is a firmware of a model. It isn't corellium.Firmware because the size of a firmware can overflow it.
+type firmware struct {
+	APIVersion    string `json:"api_version"`
+	AndroidFlavor string `json:"android_flavor"`
+	BuildID       string `json:"buildid"`
+	Filename      string `json:"filename"`
+	ID            string `json:"id"`
+	Md5Sum        string `json:"md5sum"`
+	OrigURL       string `json:"orig_url"`
+	ReleaseDate   string `json:"releasedate"`
+	Sha1Sum       string `json:"sha1sum"`
+	Sha256Sum     string `json:"sha256sum"`
+	Size          int64  `json:"size"`
+	UniqueID      string `json:"uniqueid"`
+	UploadDate    string `json:"uploaddate"`
+	URL           string `json:"url"`
+	Version       string `json:"version"`
+}
+
+// catalogModel is a model of the catalog, the cores of its instances and its firmwares.
+type catalogModel struct {
+	model     corellium.Model
+	cores     float32
+	firmwares []firmware
+}
+
+// catalog are the models supported by the server, in the order the API lists them.
+var catalog = []catalogModel{
+	{
+		model: newModel("ios", "iphone14pm", "iPhone 14 Pro Max", "iPhone15,3", "d74ap", "t8120", 33056, 14, true),
+		cores: 6,
+		firmwares: []firmware{
+			{
+				BuildID:     "20A362",
+				Filename:    "iPhone15,3_16.0_20A362_Restore.ipsw",
+				ID:          "iPhone15,3_16.0_20A362",
+				Md5Sum:      "fa07e00024db4a50a132cd4ba7575c10-788",
+				OrigURL:     "https://updates.cdn-apple.com/2022FallFCS/fullrestores/012-65663/2051812C-0862-4EA6-A896-365466C2DBAD/iPhone15,3_16.0_20A362_Restore.ipsw",
+				ReleaseDate: "2022-09-12T17:01:06Z",
+				Sha256Sum:   "4f12cc262aa87647bebc83a6ca0ae29bd11dff2ad73812cc45d920be4caaa584",
+				Size:        6609575844,
+				URL:         "https://updates.cdn-apple.com/2022FallFCS/fullrestores/012-65663/2051812C-0862-4EA6-A896-365466C2DBAD/iPhone15,3_16.0_20A362_Restore.ipsw",
+				Version:     "16.0",
+			},
+		},
+	},
+	{
+		model: newModel("ios", "iphone7plus", "iPhone 7 Plus", "iPhone9,2", "d11ap", "t8010", 32784, 10, true),
+		cores: 2,
+		firmwares: []firmware{
+			{
+				BuildID: "19H332",
+				ID:      "iPhone9,2_15.7.5_19H332",
+				Version: "15.7.5",
+			},
+		},
+	},
+	{
+		model: newModel("android", "samsung-galaxy-s-duos", "Samsung Galaxy S Duos", "samsung-galaxy-s-duos", "", "", 0, 0, false),
+		cores: 2,
+		firmwares: []firmware{
+			{
+				APIVersion:    "33",
+				AndroidFlavor: "samsung-galaxy-s-duos",
+				BuildID:       "13.0.0",
+				ID:            "samsung-galaxy-s-duos_13.0.0",
+				Version:       "13.0.0",
+			},
+		},
+	},
+	{
+		model: newModel("android", "ranchu", "Generic Android", "ranchu", "", "", 0, 0, false),
+		cores: 2,
+		firmwares: []firmware{
+			{
+				APIVersion:    "33",
+				AndroidFlavor: "ranchu",
+				BuildID:       "13.0.0",
+				ID:            "ranchu_13.0.0",
+				Version:       "13.0.0",
+			},
+		},
+	},
+}
+
+// newModel returns a model of the catalog.
+func newModel(typ, flavor, description, model, boardConfig, platform string, cpid, bdid float32, peripherals bool) corellium.Model {
+	m := corellium.NewModel(typ, flavor, flavor, model)
+	m.SetDescription(description)
+	m.SetBoardConfig(boardConfig)
+	m.SetPlatform(platform)
+	m.SetCpid(cpid)
+	m.SetBdid(bdid)
+	m.SetPeripherals(peripherals)
+
+	return *m
+}
+
+// Models returns the models supported by the server, in the order they are listed.
+func Models() []corellium.Model {
+	models := make([]corellium.Model, 0, len(catalog))
+	for _, m := range catalog {
+		models = append(models, m.model)
+	}
+
+	return models
+}
+
+// findModel returns the catalog model of the flavor, or nil when the flavor isn't supported.
+func findModel(flavor string) *corellium.Model {
+	for _, m := range catalog {
+		if m.model.GetFlavor() == flavor {
+			return &m.model
+		}
+	}
+
+	return nil
+}
+
+// findFirmware returns the firmware of the model, e.g. iPhone15,3, with the version, or nil when there isn't one.
+func findFirmware(model, version string) *firmware {
+	for _, m := range catalog {
+		if m.model.GetModel() != model {
+			continue
+		}
+
+		for _, f := range m.firmwares {
+			if f.Version == version {
+				return &f
+			}
+		}
+	}
+
+	return nil
+}
+
+// flavorCores returns the number of cores of an instance of the flavor.
+func flavorCores(flavor string) float32 {
+	for _, m := range catalog {
+		if m.model.GetFlavor() == flavor {
+			return m.cores
+		}
+	}
+
+	return 0
+}
+
+// listModels handles GET /v1/models.
+func (s *Server) listModels(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, Models())
+}
+
+// listModelSoftware handles GET /v1/models/{model}/software.
+func (s *Server) listModelSoftware(w http.ResponseWriter, r *http.Request) {
+	model := r.PathValue("model")
+
+	for _, m := range catalog {
+		if m.model.GetModel() == model || m.model.GetFlavor() == model {
+			writeJSON(w, http.StatusOK, m.firmwares)
+			return
+		}
+	}
+
+	writeNotFound(w, "Model")
+}
@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"net/http"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// NetmonPcap is the capture the server returns for an instance once its network monitor was enabled, what is a pcap
+// file without packets.
+var NetmonPcap = []byte{
+	0xd4, 0xc3, 0xb2, 0xa1, // magic number
+	0x02, 0x00, 0x04, 0x00, // version 2.4
+	0x00, 0x00, 0x00, 0x00, // time zone
+	0x00, 0x00, 0x00, 0x00, // timestamp accuracy
+	0xff, 0xff, 0x00, 0x00, // snapshot length
+	0x01, 0x00, 0x00, 0x00, // ethernet link type
+}
+
+// enableNetmon handles POST /v1/instances/{instanceId}/sslsplit/enable.
+func (s *Server) enableNetmon(w http.ResponseWriter, r *http.Request) {
+	s.setNetmon(w, r, true)
+}
+
+// disableNetmon handles POST /v1/instances/{instanceId}/sslsplit/disable.
+func (s *Server) disableNetmon(w http.ResponseWriter, r *http.Request) {
+	s.setNetmon(w, r, false)
+}
+
+// setNetmon enables or disables the network monitor of the instance, what is only possible while it is on.
+func (s *Server) setNetmon(w http.ResponseWriter, r *http.Request, enabled bool) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	if i.GetState() != corellium.ON {
+		writeError(w, http.StatusConflict, "Conflict", "The network monitor can't be changed while the instance is "+string(i.GetState()))
+		return
+	}
+
+	netmon := i.GetNetmon()
+	netmon.SetEnabled(enabled)
+	i.SetNetmon(netmon)
+
+	if enabled {
+		i.captured = true
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// netmonPcap handles GET /v1/instances/{instanceId}/networkMonitor.pcap.
+func (s *Server) netmonPcap(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	if !i.captured {
+		writeNotFound(w, "Capture")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.tcpdump.pcap")
+	w.WriteHeader(http.StatusOK)
+	w.Write(NetmonPcap)
+}
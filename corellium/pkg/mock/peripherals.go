@@ -0,0 +1,85 @@
+package mock
+
+import (
+	"net/http"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// ResetPeripherals resets the peripherals of the instance, as if it rebooted without its state changing.
+func (s *Server) ResetPeripherals(instanceId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i, ok := s.instances[instanceId]; ok {
+		i.peripherals = nil
+	}
+}
+
+// Peripherals returns a copy of the peripherals set on the instance, by the name of their field in the API.
+func (s *Server) Peripherals(instanceId string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peripherals := map[string]interface{}{}
+	if i, ok := s.instances[instanceId]; ok {
+		for k, v := range i.peripherals {
+			peripherals[k] = v
+		}
+	}
+
+	return peripherals
+}
+
+// getPeripherals handles GET /v1/instances/{instanceId}/peripherals.
+func (s *Server) getPeripherals(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.peripheralsInstance(w, r)
+	if !ok {
+		return
+	}
+
+	peripherals := i.peripherals
+	if peripherals == nil {
+		peripherals = map[string]interface{}{}
+	}
+
+	writeJSON(w, http.StatusOK, peripherals)
+}
+
+// setPeripherals handles PUT /v1/instances/{instanceId}/peripherals. The fields that are sent are merged into the
+// peripherals of the instance, the others are left as they are.
+func (s *Server) setPeripherals(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.peripheralsInstance(w, r)
+	if !ok {
+		return
+	}
+
+	var body map[string]interface{}
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if i.peripherals == nil {
+		i.peripherals = map[string]interface{}{}
+	}
+	for k, v := range body {
+		i.peripherals[k] = v
+	}
+
+	writeJSON(w, http.StatusOK, i.peripherals)
+}
+
+// peripheralsInstance returns the instance of the request, what must be on to read or set its peripherals.
+func (s *Server) peripheralsInstance(w http.ResponseWriter, r *http.Request) (*instance, bool) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	if i.GetState() != corellium.ON {
+		writeError(w, http.StatusConflict, "Conflict", "The peripherals can't be used while the instance is "+string(i.GetState()))
+		return nil, false
+	}
+
+	return i, true
+}
@@ -0,0 +1,314 @@
+package mock
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+const (
+	// DefaultProjectName is the name of the project the server starts with, what the API creates for every account.
+	DefaultProjectName = "Default Project"
+	// DefaultProjectCores is the cores quota of the default project.
+	DefaultProjectCores = 40
+
+	// instancesPerCore is the instances quota of a project for each core of its cores quota.
+	instancesPerCore = 2.5
+	// ramPerCore is the RAM quota, in MiB, of a project for each core of its cores quota.
+	ramPerCore = 6144
+)
+
+// addProject adds a project with the cores quota, and the instances and RAM quotas derived from it.
+func (s *Server) addProject(name string, settings corellium.ProjectSettings, cores float32) *corellium.Project {
+	p := corellium.NewProject(newID())
+	p.SetName(name)
+	p.SetSettings(settings)
+	p.SetQuotas(projectQuota(cores))
+
+	s.projects[p.GetId()] = p
+	s.projectOrder = append(s.projectOrder, p.GetId())
+
+	return p
+}
+
+// projectQuota returns the quotas of a project with the cores quota.
+func projectQuota(cores float32) corellium.ProjectQuota {
+	q := corellium.NewProjectQuota()
+	q.SetCores(cores)
+	q.SetInstances(cores * instancesPerCore)
+	q.SetRam(cores * ramPerCore)
+
+	return *q
+}
+
+// projectUsage returns the resources used by the instances of the project.
+func (s *Server) projectUsage(id string) corellium.ProjectUsage {
+	var cores, instances float32
+	for _, i := range s.instances {
+		if i.GetProject() == id {
+			cores += i.cores
+			instances++
+		}
+	}
+
+	u := corellium.NewProjectUsage()
+	u.SetCores(cores)
+	u.SetInstances(instances)
+	u.SetRam(cores * ramPerCore)
+	u.SetGpu(0)
+
+	return *u
+}
+
+// projectView returns the project with its current usage.
+func (s *Server) projectView(p *corellium.Project) corellium.Project {
+	v := *p
+	v.SetQuotasUsed(s.projectUsage(p.GetId()))
+
+	return v
+}
+
+// listProjects handles GET /v1/projects. The projects are listed in the order they were created, so the default
+// project is the first one.
+func (s *Server) listProjects(w http.ResponseWriter, _ *http.Request) {
+	projects := make([]corellium.Project, 0, len(s.projectOrder))
+	for _, id := range s.projectOrder {
+		projects = append(projects, s.projectView(s.projects[id]))
+	}
+
+	writeJSON(w, http.StatusOK, projects)
+}
+
+// createProject handles POST /v1/projects.
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var body corellium.Project
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if body.GetName() == "" {
+		writeValidationError(w, "name", "Project name is required")
+		return
+	}
+
+	p := s.addProject(body.GetName(), body.GetSettings(), body.Quotas.GetCores())
+
+	writeJSON(w, http.StatusOK, s.projectView(p))
+}
+
+// getProject handles GET /v1/projects/{projectId}.
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.projectView(p))
+}
+
+// updateProject handles PATCH /v1/projects/{projectId}.
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	var body corellium.Project
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if body.HasName() {
+		p.SetName(body.GetName())
+	}
+
+	if body.Settings != nil {
+		p.SetSettings(body.GetSettings())
+	}
+
+	if body.Quotas != nil && body.Quotas.HasCores() {
+		p.SetQuotas(projectQuota(body.Quotas.GetCores()))
+	}
+
+	writeJSON(w, http.StatusOK, s.projectView(p))
+}
+
+// deleteProject handles DELETE /v1/projects/{projectId}. The instances of the project are deleted with it.
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	for id, i := range s.instances {
+		if i.GetProject() == p.GetId() {
+			s.removeInstance(id)
+		}
+	}
+
+	roles := s.roles[:0]
+	for _, role := range s.roles {
+		if role.GetProject() != p.GetId() {
+			roles = append(roles, role)
+		}
+	}
+	s.roles = roles
+
+	delete(s.projects, p.GetId())
+	delete(s.keys, p.GetId())
+
+	for n, id := range s.projectOrder {
+		if id == p.GetId() {
+			s.projectOrder = append(s.projectOrder[:n], s.projectOrder[n+1:]...)
+			break
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// projectKey is a project key with the label the API client doesn't have.
+type projectKey struct {
+	corellium.ProjectKey
+	Label string
+}
+
+// MarshalJSON returns the fields of the key with its label.
+func (k projectKey) MarshalJSON() ([]byte, error) {
+	m, err := k.ProjectKey.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	m["label"] = k.Label
+
+	return json.Marshal(m)
+}
+
+// projectKeyParameters is the request body to add a project key. It isn't corellium.ProjectKey because the provider
+// sends the label the API client doesn't have.
+type projectKeyParameters struct {
+	Kind  string `json:"kind"`
+	Label string `json:"label"`
+	Key   string `json:"key"`
+}
+
+// listProjectKeys handles GET /v1/projects/{projectId}/keys.
+func (s *Server) listProjectKeys(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	keys := append([]projectKey{}, s.keys[p.GetId()]...)
+	sort.SliceStable(keys, func(a, b int) bool {
+		return keys[a].GetCreatedAt().Before(keys[b].GetCreatedAt())
+	})
+
+	writeJSON(w, http.StatusOK, keys)
+}
+
+// addProjectKey handles POST /v1/projects/{projectId}/keys.
+func (s *Server) addProjectKey(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	var body projectKeyParameters
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if body.Kind != "ssh" && body.Kind != "adb" {
+		writeValidationError(w, "kind", "Invalid key kind "+body.Kind)
+		return
+	}
+
+	if body.Key == "" {
+		writeValidationError(w, "key", "Public key is required")
+		return
+	}
+
+	now := time.Now().UTC()
+
+	key := projectKey{ProjectKey: *corellium.NewProjectKey(body.Kind, body.Key), Label: body.Label}
+	key.SetIdentifier(newID())
+	key.SetProject(p.GetId())
+	key.SetFingerprint(fingerprint(body.Key))
+	key.SetCreatedAt(now)
+	key.SetUpdatedAt(now)
+
+	s.keys[p.GetId()] = append(s.keys[p.GetId()], key)
+
+	writeJSON(w, http.StatusOK, key)
+}
+
+// removeProjectKey handles DELETE /v1/projects/{projectId}/keys/{keyId}.
+func (s *Server) removeProjectKey(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	keys := s.keys[p.GetId()]
+	for n, key := range keys {
+		if key.GetIdentifier() == r.PathValue("keyId") {
+			s.keys[p.GetId()] = append(keys[:n], keys[n+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeNotFound(w, "Project key")
+}
+
+// VPNConfig returns the OpenVPN profile the server gives for the project.
+func VPNConfig(projectId string) string {
+	return "client\ndev tap\nproto udp\nremote vpn.corellium.mock 1194\n# project " + projectId + "\n"
+}
+
+// getProjectVPNConfig handles GET /v1/projects/{projectId}/vpnconfig/{format}.
+func (s *Server) getProjectVPNConfig(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	if r.PathValue("format") != "ovpn" {
+		writeValidationError(w, "format", "The format must be ovpn")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-openvpn-profile")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, VPNConfig(p.GetId()))
+}
+
+// project returns the project of the request path, writing a not found error when it doesn't exist.
+func (s *Server) project(w http.ResponseWriter, r *http.Request) (*corellium.Project, bool) {
+	p, ok := s.projects[r.PathValue("projectId")]
+	if !ok {
+		writeNotFound(w, "Project")
+		return nil, false
+	}
+
+	return p, true
+}
+
+// fingerprint returns the MD5 fingerprint of the public key, e.g. 1f:2e:..., the way the API shows it.
+func fingerprint(key string) string {
+	sum := md5.Sum([]byte(key))
+
+	parts := make([]string, len(sum))
+	for n, b := range sum {
+		parts[n] = fmt.Sprintf("%02x", b)
+	}
+
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,272 @@
+// Package mock provides a fake Corellium API, backed by memory, to run the acceptance tests without a Corellium host.
+//
+// The server implements the endpoints called by the provider, with the same request and response models of the API
+// client. Asynchronous tasks, e.g. creating or deleting an instance, go through their intermediate states while the
+// resource is polled, so the provider waits for them the same way it does against a real host.
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/go-uuid"
+)
+
+// DefaultToken is the access token accepted by the server when none is given to NewServer.
+const DefaultToken = "mock-token"
+
+const (
+	// AllUsersTeamID is the ID of the team every user belongs to.
+	AllUsersTeamID = "all-users"
+	// AdminUsername is the username of the administrator of the account, the owner of the token.
+	AdminUsername = "admin"
+)
+
+// Server is a fake Corellium API server.
+type Server struct {
+	// Token is the access token the server accepts.
+	Token string
+
+	server *httptest.Server
+
+	mu        sync.Mutex
+	instances map[string]*instance
+	projects  map[string]*corellium.Project
+	// projectOrder are the project IDs in the order they were created.
+	projectOrder []string
+	keys         map[string][]projectKey
+	teams        map[string]*corellium.Team
+	users        map[string]*corellium.User
+	roles        []corellium.Role
+	images       map[string]*image
+	snapshots    map[string]*snapshot
+	sessions     map[string]*session
+
+	// failImageUploads is the number of the next uploads of image data that fail.
+	failImageUploads int
+}
+
+// NewServer starts a fake Corellium API server that accepts the token. An empty token falls back to DefaultToken.
+// The server must be closed when it isn't needed anymore.
+//
+// The server uses TLS, like the API, with a self-signed certificate trusted by the client returned by Client.
+func NewServer(token string) *Server {
+	if token == "" {
+		token = DefaultToken
+	}
+
+	s := &Server{
+		Token:     token,
+		instances: map[string]*instance{},
+		projects:  map[string]*corellium.Project{},
+		keys:      map[string][]projectKey{},
+		teams: map[string]*corellium.Team{
+			AllUsersTeamID: corellium.NewTeam(AllUsersTeamID, "All Users"),
+		},
+		users:     map[string]*corellium.User{},
+		images:    map[string]*image{},
+		snapshots: map[string]*snapshot{},
+		sessions:  map[string]*session{},
+	}
+
+	// NOTICE: Like an account of the API, the server starts with a default project administrated by the token owner.
+	project := s.addProject(DefaultProjectName, corellium.ProjectSettings{}, DefaultProjectCores)
+
+	admin := corellium.NewUser(newID(), AdminUsername, "Administrator", "admin@example.com")
+	admin.SetAdministrator(true)
+	s.users[admin.GetId()] = admin
+	s.roles = append(s.roles, *corellium.NewRole("admin", project.GetId(), admin.GetId()))
+
+	mux := http.NewServeMux()
+	s.routes(mux)
+
+	s.server = httptest.NewTLSServer(mux)
+
+	return s
+}
+
+// URL returns the base URL of the server, e.g. https://127.0.0.1:4321.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Host returns the host of the server, e.g. 127.0.0.1:4321, what is used as the host of the provider.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.server.URL, "https://")
+}
+
+// Client returns an HTTP client that trusts the certificate of the server.
+func (s *Server) Client() *http.Client {
+	return s.server.Client()
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// routes registers the API endpoints on the mux.
+func (s *Server) routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/ready", s.ready)
+
+	mux.HandleFunc("GET /api/v1/instances", s.auth(s.listInstances))
+	mux.HandleFunc("POST /api/v1/instances", s.auth(s.createInstance))
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}", s.auth(s.getInstance))
+	mux.HandleFunc("PATCH /api/v1/instances/{instanceId}", s.auth(s.patchInstance))
+	mux.HandleFunc("DELETE /api/v1/instances/{instanceId}", s.auth(s.deleteInstance))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/start", s.auth(s.startInstance))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/stop", s.auth(s.stopInstance))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/pause", s.auth(s.pauseInstance))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/unpause", s.auth(s.unpauseInstance))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/reboot", s.auth(s.rebootInstance))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/upgrade", s.auth(s.upgradeInstance))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/sslsplit/enable", s.auth(s.enableNetmon))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/sslsplit/disable", s.auth(s.disableNetmon))
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}/networkMonitor.pcap", s.auth(s.netmonPcap))
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}/peripherals", s.auth(s.getPeripherals))
+	mux.HandleFunc("PUT /api/v1/instances/{instanceId}/peripherals", s.auth(s.setPeripherals))
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}/hooks", s.auth(s.listHooks))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/hooks", s.auth(s.createHook))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/hooks/execute", s.auth(s.executeHooks))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/hooks/clear", s.auth(s.clearHooks))
+	mux.HandleFunc("GET /api/v1/hooks/{hookId}", s.auth(s.getHook))
+	mux.HandleFunc("PUT /api/v1/hooks/{hookId}", s.auth(s.updateHook))
+	mux.HandleFunc("DELETE /api/v1/hooks/{hookId}", s.auth(s.deleteHook))
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}/strace", s.auth(s.coreTraceLog))
+	mux.HandleFunc("PUT /api/v1/instances/{instanceId}/strace", s.auth(s.setCoreTraceFilter))
+	mux.HandleFunc("DELETE /api/v1/instances/{instanceId}/strace", s.auth(s.clearCoreTraceLog))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/strace/enable", s.auth(s.startCoreTrace))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/strace/disable", s.auth(s.stopCoreTrace))
+
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}/snapshots", s.auth(s.listSnapshots))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/snapshots", s.auth(s.createSnapshot))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/snapshots/{snapshotId}/restore", s.auth(s.restoreSnapshot))
+	mux.HandleFunc("GET /api/v1/snapshots/{snapshotId}", s.auth(s.getSnapshot))
+	mux.HandleFunc("PATCH /api/v1/snapshots/{snapshotId}", s.auth(s.renameSnapshot))
+	mux.HandleFunc("DELETE /api/v1/snapshots/{snapshotId}", s.auth(s.deleteSnapshot))
+
+	mux.HandleFunc("GET /api/v1/projects", s.auth(s.listProjects))
+	mux.HandleFunc("POST /api/v1/projects", s.auth(s.createProject))
+	mux.HandleFunc("GET /api/v1/projects/{projectId}", s.auth(s.getProject))
+	mux.HandleFunc("PATCH /api/v1/projects/{projectId}", s.auth(s.updateProject))
+	mux.HandleFunc("DELETE /api/v1/projects/{projectId}", s.auth(s.deleteProject))
+	mux.HandleFunc("GET /api/v1/projects/{projectId}/keys", s.auth(s.listProjectKeys))
+	mux.HandleFunc("POST /api/v1/projects/{projectId}/keys", s.auth(s.addProjectKey))
+	mux.HandleFunc("DELETE /api/v1/projects/{projectId}/keys/{keyId}", s.auth(s.removeProjectKey))
+	mux.HandleFunc("GET /api/v1/projects/{projectId}/vpnconfig/{format}", s.auth(s.getProjectVPNConfig))
+
+	mux.HandleFunc("GET /api/v1/teams", s.auth(s.listTeams))
+	mux.HandleFunc("POST /api/v1/teams", s.auth(s.createTeam))
+	mux.HandleFunc("PATCH /api/v1/teams/{teamId}", s.auth(s.changeTeam))
+	mux.HandleFunc("DELETE /api/v1/teams/{teamId}", s.auth(s.deleteTeam))
+	mux.HandleFunc("PUT /api/v1/teams/{teamId}/users/{userId}", s.auth(s.addUserToTeam))
+	mux.HandleFunc("DELETE /api/v1/teams/{teamId}/users/{userId}", s.auth(s.removeUserFromTeam))
+
+	mux.HandleFunc("POST /api/v1/users", s.auth(s.createUser))
+	mux.HandleFunc("PATCH /api/v1/users/{userId}", s.auth(s.updateUser))
+	mux.HandleFunc("DELETE /api/v1/users/{userId}", s.auth(s.deleteUser))
+
+	mux.HandleFunc("GET /api/v1/roles", s.auth(s.listRoles))
+	mux.HandleFunc("PUT /api/v1/roles/projects/{projectId}/users/{userId}/roles/{roleId}", s.auth(s.addRole("userId")))
+	mux.HandleFunc("DELETE /api/v1/roles/projects/{projectId}/users/{userId}/roles/{roleId}", s.auth(s.removeRole("userId")))
+	mux.HandleFunc("PUT /api/v1/roles/projects/{projectId}/teams/{teamId}/roles/{roleId}", s.auth(s.addRole("teamId")))
+	mux.HandleFunc("DELETE /api/v1/roles/projects/{projectId}/teams/{teamId}/roles/{roleId}", s.auth(s.removeRole("teamId")))
+
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}/agent/v1/app/ready", s.auth(s.agentAppReady))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/agent/v1/system/getprop", s.auth(s.agentGetProp))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/agent/v1/file/temp", s.auth(s.agentTempFilename))
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}/agent/v1/file/device/{filePath}", s.auth(s.agentGetFile))
+	mux.HandleFunc("PUT /api/v1/instances/{instanceId}/agent/v1/file/device/{filePath}", s.auth(s.agentUploadFile))
+	mux.HandleFunc("DELETE /api/v1/instances/{instanceId}/agent/v1/file/device/{filePath}", s.auth(s.agentDeleteFile))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/agent/v1/app/install", s.auth(s.agentInstallApp))
+	mux.HandleFunc("GET /api/v1/instances/{instanceId}/agent/v1/app/apps", s.auth(s.agentListApps))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/agent/v1/app/apps/{bundleId}/run", s.auth(s.agentRunApp(true)))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/agent/v1/app/apps/{bundleId}/kill", s.auth(s.agentRunApp(false)))
+	mux.HandleFunc("POST /api/v1/instances/{instanceId}/agent/v1/app/apps/{bundleId}/uninstall", s.auth(s.agentUninstallApp))
+
+	mux.HandleFunc("GET /api/v1/images", s.auth(s.listImages))
+	mux.HandleFunc("POST /api/v1/images", s.auth(s.createImage))
+	mux.HandleFunc("GET /api/v1/images/{imageId}", s.auth(s.getImage))
+	mux.HandleFunc("POST /api/v1/images/{imageId}", s.auth(s.uploadImageData))
+	mux.HandleFunc("DELETE /api/v2/images/{imageId}", s.auth(s.deleteImage))
+
+	mux.HandleFunc("GET /api/v1/models", s.auth(s.listModels))
+	mux.HandleFunc("GET /api/v1/models/{model}/software", s.auth(s.listModelSoftware))
+
+	mux.HandleFunc("POST /api/v1/webplayer", s.auth(s.createSession))
+	mux.HandleFunc("GET /api/v1/webplayer/{sessionId}", s.auth(s.getSession))
+	mux.HandleFunc("DELETE /api/v1/webplayer/{sessionId}", s.auth(s.deleteSession))
+}
+
+// auth rejects the requests without the server token.
+func (s *Server) auth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.Token {
+			writeError(w, http.StatusUnauthorized, "Unauthorized", "Invalid or missing access token")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		next(w, r)
+	}
+}
+
+// ready handles the status endpoint, what doesn't require a token.
+func (s *Server) ready(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// apiError is the error model returned by the API.
+type apiError struct {
+	Error   string `json:"error"`
+	ErrorID string `json:"errorID"`
+	Field   string `json:"field,omitempty"`
+}
+
+// writeJSON writes the value as the JSON response body.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an API error response.
+func writeError(w http.ResponseWriter, status int, errorID, message string) {
+	writeJSON(w, status, apiError{Error: message, ErrorID: errorID})
+}
+
+// writeValidationError writes an API error response for an invalid request field.
+func writeValidationError(w http.ResponseWriter, field, message string) {
+	writeJSON(w, http.StatusBadRequest, apiError{Error: message, ErrorID: "ValidationError", Field: field})
+}
+
+// writeNotFound writes the API error response for a resource that doesn't exist.
+func writeNotFound(w http.ResponseWriter, kind string) {
+	writeError(w, http.StatusNotFound, "NotFound", kind+" not found")
+}
+
+// readJSON decodes the request body into the value, writing a validation error when it isn't valid.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeValidationError(w, "", "Invalid request body: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+// newID returns a new random UUID.
+func newID() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
@@ -0,0 +1,111 @@
+package mock
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+func newTestClient(t *testing.T, token string) (context.Context, *corellium.APIClient) {
+	t.Helper()
+
+	s := NewServer("")
+	t.Cleanup(s.Close)
+
+	if token == "" {
+		token = s.Token
+	}
+
+	configuration := corellium.NewConfiguration()
+	configuration.Host = s.Host()
+	configuration.HTTPClient = s.Client()
+
+	return context.WithValue(context.Background(), corellium.ContextAccessToken, token), corellium.NewAPIClient(configuration)
+}
+
+func TestServer_unauthorized(t *testing.T) {
+	auth, client := newTestClient(t, "invalid")
+
+	_, r, err := client.ProjectsApi.V1GetProjects(auth).Execute()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if r.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, r.StatusCode)
+	}
+}
+
+func TestServer_instanceLifecycle(t *testing.T) {
+	auth, client := newTestClient(t, "")
+
+	projects, _, err := client.ProjectsApi.V1GetProjects(auth).Execute()
+	if err != nil {
+		t.Fatalf("unexpected error listing the projects: %s", err)
+	}
+
+	if len(projects) != 1 || projects[0].GetName() != DefaultProjectName {
+		t.Fatalf("expected the default project, got %v", projects)
+	}
+
+	o := corellium.NewInstanceCreateOptions("iphone7plus", projects[0].GetId(), "15.7.5")
+	created, _, err := client.InstancesApi.V1CreateInstance(auth).InstanceCreateOptions(*o).Execute()
+	if err != nil {
+		t.Fatalf("unexpected error creating the instance: %s", err)
+	}
+
+	for _, want := range []corellium.InstanceState{corellium.CREATING, corellium.BOOTING, corellium.ON, corellium.ON} {
+		instance, _, err := client.InstancesApi.V1GetInstance(auth, created.GetId()).Execute()
+		if err != nil {
+			t.Fatalf("unexpected error getting the instance: %s", err)
+		}
+
+		if instance.GetState() != want {
+			t.Fatalf("expected state %q, got %q", want, instance.GetState())
+		}
+	}
+
+	if _, err := client.InstancesApi.V1DeleteInstance(auth, created.GetId()).Execute(); err != nil {
+		t.Fatalf("unexpected error deleting the instance: %s", err)
+	}
+
+	instance, _, err := client.InstancesApi.V1GetInstance(auth, created.GetId()).Execute()
+	if err != nil {
+		t.Fatalf("unexpected error getting the instance: %s", err)
+	}
+
+	if instance.GetState() != corellium.DELETING {
+		t.Fatalf("expected state %q, got %q", corellium.DELETING, instance.GetState())
+	}
+
+	_, r, err := client.InstancesApi.V1GetInstance(auth, created.GetId()).Execute()
+	if err == nil || r.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected the instance to be deleted, got %v", err)
+	}
+}
+
+func TestServer_createInstanceValidation(t *testing.T) {
+	auth, client := newTestClient(t, "")
+
+	projects, _, err := client.ProjectsApi.V1GetProjects(auth).Execute()
+	if err != nil {
+		t.Fatalf("unexpected error listing the projects: %s", err)
+	}
+
+	o := corellium.NewInstanceCreateOptions("iphone7plus", projects[0].GetId(), "1.0")
+	_, r, err := client.InstancesApi.V1CreateInstance(auth).InstanceCreateOptions(*o).Execute()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if r.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, r.StatusCode)
+	}
+
+	if body := string(err.(*corellium.GenericOpenAPIError).Body()); !strings.Contains(body, `"field":"os"`) {
+		t.Errorf("expected a validation error for the os field, got %s", body)
+	}
+}
@@ -0,0 +1,183 @@
+package mock
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// snapshot is a snapshot and the times it has to be read before its task is done.
+type snapshot struct {
+	corellium.Snapshot
+
+	// polls is the number of times the snapshot is read before it is created, or deleted.
+	polls int
+	// deleting tells whether the snapshot is deleted once the polls are done.
+	deleting bool
+}
+
+// addSnapshot adds a snapshot of the instance. A fresh snapshot is created right away, any other goes through the
+// creating task first.
+func (s *Server) addSnapshot(instanceID, name string, fresh bool) *snapshot {
+	snap := &snapshot{Snapshot: *corellium.NewSnapshot()}
+	snap.SetId(newID())
+	snap.SetName(name)
+	snap.SetInstance(instanceID)
+	snap.SetDate(float32(time.Now().Unix()))
+	snap.SetFresh(fresh)
+	snap.SetLive(false)
+	snap.SetLocal(true)
+
+	status := corellium.NewSnapshotStatus()
+	if fresh {
+		status.SetTask("none")
+		status.SetCreated(true)
+	} else {
+		status.SetTask("creating")
+		status.SetCreated(false)
+		snap.polls = 1
+	}
+
+	snap.SetStatus(*status)
+
+	s.snapshots[snap.GetId()] = snap
+
+	return snap
+}
+
+// listSnapshots handles GET /v1/instances/{instanceId}/snapshots.
+func (s *Server) listSnapshots(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	snapshots := []*snapshot{}
+	for _, snap := range s.snapshots {
+		if snap.GetInstance() == i.GetId() {
+			snapshots = append(snapshots, snap)
+		}
+	}
+
+	sort.SliceStable(snapshots, func(a, b int) bool {
+		return snapshots[a].GetDate() < snapshots[b].GetDate()
+	})
+
+	body := make([]corellium.Snapshot, 0, len(snapshots))
+	for _, snap := range snapshots {
+		body = append(body, snap.Snapshot)
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+// createSnapshot handles POST /v1/instances/{instanceId}/snapshots.
+func (s *Server) createSnapshot(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	var opts corellium.SnapshotCreationOptions
+	if !readJSON(w, r, &opts) {
+		return
+	}
+
+	if opts.GetName() == "" {
+		writeValidationError(w, "name", "Snapshot name is required")
+		return
+	}
+
+	snap := s.addSnapshot(i.GetId(), opts.GetName(), false)
+
+	writeJSON(w, http.StatusOK, snap.Snapshot)
+}
+
+// restoreSnapshot handles POST /v1/instances/{instanceId}/snapshots/{snapshotId}/restore.
+func (s *Server) restoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.instance(w, r)
+	if !ok {
+		return
+	}
+
+	snap, ok := s.snapshots[r.PathValue("snapshotId")]
+	if !ok || snap.GetInstance() != i.GetId() {
+		writeNotFound(w, "Snapshot")
+		return
+	}
+
+	i.transition(corellium.RESTORING, corellium.ON)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getSnapshot handles GET /v1/snapshots/{snapshotId}. It responds with the current status of the snapshot, and then
+// moves its task forward.
+func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshot(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snap.Snapshot)
+
+	if snap.polls > 0 {
+		snap.polls--
+		return
+	}
+
+	if snap.deleting {
+		delete(s.snapshots, snap.GetId())
+		return
+	}
+
+	status := snap.GetStatus()
+	status.SetTask("none")
+	status.SetCreated(true)
+	snap.SetStatus(status)
+}
+
+// renameSnapshot handles PATCH /v1/snapshots/{snapshotId}.
+func (s *Server) renameSnapshot(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshot(w, r)
+	if !ok {
+		return
+	}
+
+	var opts corellium.SnapshotCreationOptions
+	if !readJSON(w, r, &opts) {
+		return
+	}
+
+	snap.SetName(opts.GetName())
+
+	writeJSON(w, http.StatusOK, snap.Snapshot)
+}
+
+// deleteSnapshot handles DELETE /v1/snapshots/{snapshotId}.
+func (s *Server) deleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshot(w, r)
+	if !ok {
+		return
+	}
+
+	status := snap.GetStatus()
+	status.SetTask("deleting")
+	snap.SetStatus(status)
+	snap.deleting = true
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// snapshot returns the snapshot of the request path, writing a not found error when it doesn't exist.
+func (s *Server) snapshot(w http.ResponseWriter, r *http.Request) (*snapshot, bool) {
+	snap, ok := s.snapshots[r.PathValue("snapshotId")]
+	if !ok {
+		writeNotFound(w, "Snapshot")
+		return nil, false
+	}
+
+	return snap, true
+}
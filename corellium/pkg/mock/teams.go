@@ -0,0 +1,315 @@
+package mock
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// userCreateOptions is the request body to create, or update, a user.
+type userCreateOptions struct {
+	Name          string `json:"name"`
+	Label         string `json:"label"`
+	Email         string `json:"email"`
+	Password      string `json:"password"`
+	Administrator bool   `json:"administrator"`
+}
+
+// listTeams handles GET /v1/teams. The users of the teams are listed with them, and every user is in the all users
+// team.
+func (s *Server) listTeams(w http.ResponseWriter, _ *http.Request) {
+	all := s.teams[AllUsersTeamID]
+	all.Users = make([]corellium.User, 0, len(s.users))
+	for _, u := range s.users {
+		all.Users = append(all.Users, *u)
+	}
+
+	teams := make([]corellium.Team, 0, len(s.teams))
+	for _, t := range s.teams {
+		sort.SliceStable(t.Users, func(a, b int) bool {
+			return t.Users[a].Label < t.Users[b].Label
+		})
+
+		teams = append(teams, *t)
+	}
+
+	sort.SliceStable(teams, func(a, b int) bool {
+		return teams[a].Label < teams[b].Label
+	})
+
+	writeJSON(w, http.StatusOK, teams)
+}
+
+// createTeam handles POST /v1/teams.
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request) {
+	var body corellium.CreateTeam
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if body.GetName() == "" {
+		writeValidationError(w, "name", "Team name is required")
+		return
+	}
+
+	t := corellium.NewTeam(newID(), body.GetName())
+	t.Users = []corellium.User{}
+	s.teams[t.GetId()] = t
+
+	created := corellium.NewTeamCreate()
+	created.SetId(t.GetId())
+
+	writeJSON(w, http.StatusOK, created)
+}
+
+// changeTeam handles PATCH /v1/teams/{teamId}.
+func (s *Server) changeTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(w, r)
+	if !ok {
+		return
+	}
+
+	var body corellium.CreateTeam
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if body.GetName() != "" {
+		t.SetLabel(body.GetName())
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteTeam handles DELETE /v1/teams/{teamId}.
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(w, r)
+	if !ok {
+		return
+	}
+
+	if t.GetId() == AllUsersTeamID {
+		writeError(w, http.StatusForbidden, "Forbidden", "The all users team can't be deleted")
+		return
+	}
+
+	delete(s.teams, t.GetId())
+	s.removeRoles(t.GetId())
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// addUserToTeam handles PUT /v1/teams/{teamId}/users/{userId}.
+func (s *Server) addUserToTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(w, r)
+	if !ok {
+		return
+	}
+
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+
+	for _, member := range t.Users {
+		if member.GetId() == u.GetId() {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	t.Users = append(t.Users, *u)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeUserFromTeam handles DELETE /v1/teams/{teamId}/users/{userId}.
+func (s *Server) removeUserFromTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.team(w, r)
+	if !ok {
+		return
+	}
+
+	for n, member := range t.Users {
+		if member.GetId() == r.PathValue("userId") {
+			t.Users = append(t.Users[:n], t.Users[n+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeNotFound(w, "User")
+}
+
+// createUser handles POST /v1/users. It responds with the ID of the user only, like the API.
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var body userCreateOptions
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	for _, u := range s.users {
+		if u.GetLabel() == body.Label {
+			writeError(w, http.StatusConflict, "Conflict", "A user with the username "+body.Label+" already exists")
+			return
+		}
+	}
+
+	u := corellium.NewUser(newID(), body.Label, body.Name, body.Email)
+	u.SetAdministrator(body.Administrator)
+	s.users[u.GetId()] = u
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"id": u.GetId()})
+}
+
+// updateUser handles PATCH /v1/users/{userId}.
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+
+	var body userCreateOptions
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	u.SetName(body.Name)
+	u.SetLabel(body.Label)
+	u.SetEmail(body.Email)
+	u.SetAdministrator(body.Administrator)
+
+	for _, t := range s.teams {
+		for n, member := range t.Users {
+			if member.GetId() == u.GetId() {
+				t.Users[n] = *u
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// deleteUser handles DELETE /v1/users/{userId}.
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.users, u.GetId())
+	s.removeRoles(u.GetId())
+
+	for _, t := range s.teams {
+		for n, member := range t.Users {
+			if member.GetId() == u.GetId() {
+				t.Users = append(t.Users[:n], t.Users[n+1:]...)
+				break
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// listRoles handles GET /v1/roles. The roles of teams have the team ID as the user.
+func (s *Server) listRoles(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, append([]corellium.Role{}, s.roles...))
+}
+
+// addRole returns the handler of PUT /v1/roles/projects/{projectId}/{users|teams}/{id}/roles/{roleId}, where the
+// param is the path value of the user, or team, ID.
+func (s *Server) addRole(param string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, ok := s.project(w, r)
+		if !ok {
+			return
+		}
+
+		id := r.PathValue(param)
+		if _, ok := s.users[id]; !ok && param == "userId" {
+			writeNotFound(w, "User")
+			return
+		}
+
+		if _, ok := s.teams[id]; !ok && param == "teamId" {
+			writeNotFound(w, "Team")
+			return
+		}
+
+		role := r.PathValue("roleId")
+		if role != "admin" && role != "user" {
+			writeValidationError(w, "role", "Invalid role "+role)
+			return
+		}
+
+		for n, existing := range s.roles {
+			if existing.GetProject() == p.GetId() && existing.GetUser() == id {
+				s.roles[n].SetRole(role)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		s.roles = append(s.roles, *corellium.NewRole(role, p.GetId(), id))
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// removeRole returns the handler of DELETE /v1/roles/projects/{projectId}/{users|teams}/{id}/roles/{roleId}, where
+// the param is the path value of the user, or team, ID.
+func (s *Server) removeRole(param string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, ok := s.project(w, r)
+		if !ok {
+			return
+		}
+
+		id := r.PathValue(param)
+		for n, existing := range s.roles {
+			if existing.GetProject() == p.GetId() && existing.GetUser() == id && existing.GetRole() == r.PathValue("roleId") {
+				s.roles = append(s.roles[:n], s.roles[n+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		writeNotFound(w, "Role")
+	}
+}
+
+// removeRoles removes the roles of the user, or team.
+func (s *Server) removeRoles(id string) {
+	roles := s.roles[:0]
+	for _, role := range s.roles {
+		if role.GetUser() != id {
+			roles = append(roles, role)
+		}
+	}
+
+	s.roles = roles
+}
+
+// team returns the team of the request path, writing a not found error when it doesn't exist.
+func (s *Server) team(w http.ResponseWriter, r *http.Request) (*corellium.Team, bool) {
+	t, ok := s.teams[r.PathValue("teamId")]
+	if !ok {
+		writeNotFound(w, "Team")
+		return nil, false
+	}
+
+	return t, true
+}
+
+// user returns the user of the request path, writing a not found error when it doesn't exist.
+func (s *Server) user(w http.ResponseWriter, r *http.Request) (*corellium.User, bool) {
+	u, ok := s.users[r.PathValue("userId")]
+	if !ok {
+		writeNotFound(w, "User")
+		return nil, false
+	}
+
+	return u, true
+}
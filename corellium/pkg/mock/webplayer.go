@@ -0,0 +1,118 @@
+package mock
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+)
+
+// expirationFormat is the ISO-8601 format of the expiration of the sessions, e.g. 2022-05-06T02:39:23.000Z.
+const expirationFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// session is a web player session, in the format the API returns its details.
+type session struct {
+	Identifier       string             `json:"identifier"`
+	Token            string             `json:"token"`
+	InstanceID       string             `json:"instanceId"`
+	Project          string             `json:"project"`
+	ClientID         string             `json:"clientId"`
+	Expiration       string             `json:"expiration"`
+	ExpiresInSeconds float32            `json:"expiresInSeconds"`
+	Features         corellium.Features `json:"features"`
+
+	// expiresAt is the time the session expires.
+	expiresAt time.Time
+}
+
+// createdSession is the response of the create endpoint, with the expiration in milliseconds since the epoch.
+type createdSession struct {
+	Identifier string `json:"identifier"`
+	Token      string `json:"token"`
+	Expiration int64  `json:"expiration"`
+}
+
+// ExpireSessions expires the web player sessions, as if their expiration passed.
+func (s *Server) ExpireSessions() {
+	s.ExpireSessionsIn(0)
+}
+
+// ExpireSessionsIn sets the web player sessions to expire after the duration, as if their lifetime passed until then.
+func (s *Server) ExpireSessionsIn(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt := time.Now().UTC().Add(d).Truncate(time.Millisecond)
+	for _, sess := range s.sessions {
+		sess.expiresAt = expiresAt
+		sess.Expiration = expiresAt.Format(expirationFormat)
+	}
+}
+
+// createSession handles POST /v1/webplayer. The expiration of the created session is in milliseconds since the epoch,
+// like the API.
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request) {
+	var body corellium.WebPlayerCreateSessionRequest
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	if _, ok := s.projects[body.GetProjectId()]; !ok {
+		writeNotFound(w, "Project")
+		return
+	}
+
+	i, ok := s.instances[body.GetInstanceId()]
+	if !ok || i.GetProject() != body.GetProjectId() {
+		writeNotFound(w, "Instance")
+		return
+	}
+
+	if body.GetExpiresIn() <= 0 {
+		writeValidationError(w, "expiresIn", "The expiresIn field must be greater than zero")
+		return
+	}
+
+	expiresAt := time.Now().UTC().Add(time.Duration(body.GetExpiresIn()) * time.Second).Truncate(time.Millisecond)
+
+	sess := &session{
+		Identifier:       newID(),
+		Token:            newID(),
+		InstanceID:       body.GetInstanceId(),
+		Project:          body.GetProjectId(),
+		ClientID:         body.GetClientId(),
+		Expiration:       expiresAt.Format(expirationFormat),
+		ExpiresInSeconds: body.GetExpiresIn(),
+		Features:         body.GetFeatures(),
+		expiresAt:        expiresAt,
+	}
+
+	s.sessions[sess.Identifier] = sess
+
+	// NOTICE: The expiration isn't a corellium.WebPlayerSession, as its float32 can't hold the milliseconds.
+	writeJSON(w, http.StatusOK, createdSession{Identifier: sess.Identifier, Token: sess.Token, Expiration: expiresAt.UnixMilli()})
+}
+
+// getSession handles GET /v1/webplayer/{sessionId}. It responds with a list with the session, or a not found error
+// once the session expires.
+func (s *Server) getSession(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.sessions[r.PathValue("sessionId")]
+	if !ok || time.Now().After(sess.expiresAt) {
+		writeNotFound(w, "Session")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, []*session{sess})
+}
+
+// deleteSession handles DELETE /v1/webplayer/{sessionId}.
+func (s *Server) deleteSession(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.sessions[r.PathValue("sessionId")]; !ok {
+		writeNotFound(w, "Session")
+		return
+	}
+
+	delete(s.sessions, r.PathValue("sessionId"))
+
+	w.WriteHeader(http.StatusNoContent)
+}
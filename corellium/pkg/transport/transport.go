@@ -0,0 +1,228 @@
+// Package transport provides an HTTP transport that retries failed requests and limits the concurrent requests to the
+// Corellium API.
+package transport
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a request is retried.
+	DefaultMaxRetries = 4
+	// DefaultRetryMinWait is the default time to wait before the first retry.
+	DefaultRetryMinWait = 1 * time.Second
+	// DefaultRetryMaxWait is the default maximum time to wait between retries.
+	DefaultRetryMaxWait = 30 * time.Second
+	// DefaultMaxConcurrentRequests is the default maximum number of requests in flight at the same time.
+	DefaultMaxConcurrentRequests = 10
+)
+
+// Transport is a http.RoundTripper that retries requests failed by a connection error, a 429 or a 5xx response,
+// using an exponential backoff that honors the Retry-After header, and that limits the number of requests in flight.
+//
+// Only idempotent requests are retried on connection errors and 5xx responses, i.e. the ones with an idempotent method
+// or a context from WithIdempotent. A 429 response means the request wasn't processed, so any request is retried on it.
+type Transport struct {
+	// Base is the transport used to send the requests.
+	Base http.RoundTripper
+	// MaxRetries is the number of times a request is retried. Zero disables the retries.
+	MaxRetries int
+	// RetryMinWait is the time to wait before the first retry, doubled on each retry.
+	RetryMinWait time.Duration
+	// RetryMaxWait is the maximum time to wait between retries, including the Retry-After header.
+	RetryMaxWait time.Duration
+
+	// semaphore limits the requests in flight. It is nil when there is no limit.
+	semaphore chan struct{}
+}
+
+// New returns a Transport that sends the requests through the base transport. A maxConcurrentRequests of zero, or
+// less, doesn't limit the requests in flight.
+func New(base http.RoundTripper, maxRetries int, retryMaxWait time.Duration, maxConcurrentRequests int) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t := &Transport{
+		Base:         base,
+		MaxRetries:   maxRetries,
+		RetryMinWait: DefaultRetryMinWait,
+		RetryMaxWait: retryMaxWait,
+	}
+
+	if retryMaxWait < t.RetryMinWait {
+		t.RetryMinWait = retryMaxWait
+	}
+
+	if maxConcurrentRequests > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return t
+}
+
+// idempotentKey is the context key of the requests that can be sent again whatever their method is.
+type idempotentKey struct{}
+
+// WithIdempotent returns a context that marks the requests sent with it as idempotent, so they're retried like a PUT,
+// e.g. a POST that replaces the data of an existing object.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		if err := t.acquire(req); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.Base.RoundTrip(r)
+		t.release()
+
+		if attempt >= t.MaxRetries || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		if resp != nil {
+			// The body must be drained and closed, so the connection can be reused.
+			drain(resp)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// acquire waits for a free slot to send the request, when the requests in flight are limited.
+func (t *Transport) acquire(req *http.Request) error {
+	if t.semaphore == nil {
+		return nil
+	}
+
+	select {
+	case t.semaphore <- struct{}{}:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// release frees the slot taken by acquire.
+func (t *Transport) release() {
+	if t.semaphore == nil {
+		return
+	}
+
+	<-t.semaphore
+}
+
+// retryable reports whether the request should be sent again.
+func (t *Transport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	// A request with a body that can't be read again can't be retried.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+
+	if err != nil {
+		return marked || idempotent(req.Method)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented:
+		return marked || idempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// backoff returns the time to wait before the next retry. It is the Retry-After header, when the response has one,
+// or an exponential backoff from RetryMinWait otherwise, never longer than RetryMaxWait.
+func (t *Transport) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := time.Duration(float64(t.RetryMinWait) * math.Pow(2, float64(attempt)))
+
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			wait = after
+		}
+	}
+
+	if wait > t.RetryMaxWait {
+		wait = t.RetryMaxWait
+	}
+
+	return wait
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or a HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// idempotent reports whether a request with the method can be sent again without side effects.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// drain reads what is left of the response body, up to a limit, and closes it.
+func drain(resp *http.Response) {
+	const limit = 4096
+
+	_, _ = io.CopyN(io.Discard, resp.Body, limit)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,183 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransport_retries(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		idempotent bool
+		statuses   []int
+		want       int
+		calls      int32
+	}{
+		{
+			name:     "retries a get on a server error",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			want:     http.StatusOK,
+			calls:    3,
+		},
+		{
+			name:     "retries a post on too many requests",
+			method:   http.MethodPost,
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			want:     http.StatusOK,
+			calls:    2,
+		},
+		{
+			name:     "doesn't retry a post on a server error",
+			method:   http.MethodPost,
+			statuses: []int{http.StatusInternalServerError, http.StatusOK},
+			want:     http.StatusInternalServerError,
+			calls:    1,
+		},
+		{
+			name:       "retries a post marked idempotent on a server error",
+			method:     http.MethodPost,
+			idempotent: true,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			want:       http.StatusOK,
+			calls:      2,
+		},
+		{
+			name:     "doesn't retry a client error",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusNotFound, http.StatusOK},
+			want:     http.StatusNotFound,
+			calls:    1,
+		},
+		{
+			name:     "gives up after the max retries",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			want:     http.StatusBadGateway,
+			calls:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+
+				b, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(b) != "body" {
+					t.Errorf("got body %q on call %d, want %q", string(b), n, "body")
+				}
+
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: New(nil, 2, time.Millisecond, 0)}
+
+			ctx := context.Background()
+			if tt.idempotent {
+				ctx = WithIdempotent(ctx)
+			}
+
+			req, err := http.NewRequestWithContext(ctx, tt.method, server.URL, strings.NewReader("body"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.want)
+			}
+
+			if calls != tt.calls {
+				t.Errorf("got %d calls, want %d", calls, tt.calls)
+			}
+		})
+	}
+}
+
+func TestTransport_backoff(t *testing.T) {
+	tr := New(nil, 4, 10*time.Second, 0)
+
+	header := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	tests := []struct {
+		name    string
+		attempt int
+		resp    *http.Response
+		want    time.Duration
+	}{
+		{name: "first retry", attempt: 0, want: 1 * time.Second},
+		{name: "third retry", attempt: 2, want: 4 * time.Second},
+		{name: "capped at max wait", attempt: 6, want: 10 * time.Second},
+		{name: "retry after seconds", attempt: 0, resp: header("3"), want: 3 * time.Second},
+		{name: "retry after capped at max wait", attempt: 0, resp: header("120"), want: 10 * time.Second},
+		{name: "invalid retry after", attempt: 1, resp: header("soon"), want: 2 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tr.backoff(tt.attempt, tt.resp); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransport_max_concurrent_requests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: New(nil, 0, time.Millisecond, 2)}
+
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("got %d requests in flight, want at most 2", maxInFlight)
+	}
+}
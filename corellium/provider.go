@@ -3,16 +3,21 @@ package corellium
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-corellium/corellium/pkg/api"
+	"terraform-provider-corellium/corellium/pkg/transport"
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -20,6 +25,12 @@ var (
 	_ provider.Provider = &corelliumProvider{}
 )
 
+// pollWait returns the time to wait between the requests that poll the API for a change, e.g. an instance to be on. The
+// acceptance tests shorten it when they run against the mock API, what changes without delay.
+var pollWait = func(d time.Duration) time.Duration {
+	return d
+}
+
 // New is a helper function to simplify provider server and testing implementation.
 func New() provider.Provider {
 	return &corelliumProvider{}
@@ -34,6 +45,12 @@ type corelliumProvider struct{}
 type corelliumProviderModel struct {
 	Token types.String `tfsdk:"token"`
 	Host  types.String `tfsdk:"host"`
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries types.Int64 `tfsdk:"max_retries"`
+	// RetryMaxWait is the maximum time, in seconds, to wait between retries.
+	RetryMaxWait types.Int64 `tfsdk:"retry_max_wait"`
+	// MaxConcurrentRequests is the maximum number of requests to the API in flight at the same time.
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
 }
 
 // Metadata returns the provider type name.
@@ -54,6 +71,27 @@ func (p *corelliumProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Description: "The Corellium API host. This can also be set via the CORELLIUM_API_HOST environment variable.",
 				Optional:    true,
 			},
+			"max_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of times a request failed by a connection error, a 429 or a 5xx response is retried. Default is %d.", transport.DefaultMaxRetries),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum time, in seconds, to wait between retries, including the Retry-After header. Default is %d.", int64(transport.DefaultRetryMaxWait/time.Second)),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of requests to the Corellium API in flight at the same time. Default is %d.", transport.DefaultMaxConcurrentRequests),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -133,8 +171,28 @@ func (p *corelliumProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
+	maxRetries := transport.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	retryMaxWait := transport.DefaultRetryMaxWait
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWait = time.Duration(config.RetryMaxWait.ValueInt64()) * time.Second
+	}
+
+	maxConcurrentRequests := transport.DefaultMaxConcurrentRequests
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+	}
+
 	configuration := corellium.NewConfiguration()
 	configuration.Host = host
+	// NOTICE: All the resources and data sources share the same HTTP client, so the retries and the limit of
+	// concurrent requests apply to the whole provider.
+	configuration.HTTPClient = &http.Client{
+		Transport: transport.New(http.DefaultTransport, maxRetries, retryMaxWait, maxConcurrentRequests),
+	}
 
 	client := corellium.NewAPIClient(configuration)
 	r, err := client.StatusApi.V1Ready(ctx).Execute()
@@ -157,6 +215,10 @@ func (p *corelliumProvider) Configure(ctx context.Context, req provider.Configur
 func (p *corelliumProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewCorelliumV1ReadyDataSource,
+		NewCorelliumV1InstanceDataSource,
+		NewCorelliumV1InstanceReadyDataSource,
+		NewCorelliumV1InstancePcapDataSource,
+		NewCorelliumV1ProjectVPNConfigDataSource,
 		NewCorelliumV1InstancesSource,
 		NewCorelliumV1SupportedModelsDataSource,
 		NewCorelliumV1ModelSoftwareDataSource,
@@ -175,5 +237,11 @@ func (p *corelliumProvider) Resources(_ context.Context) []func() resource.Resou
 		NewCorelliumV1SnapshotResource,
 		NewCorelliumV1InstanceResource,
 		NewCorelliumV1WebPlayerResource,
+		NewCorelliumV1InstanceActionResource,
+		NewCorelliumV1InstanceAppResource,
+		NewCorelliumV1InstanceFileResource,
+		NewCorelliumV1InstanceSensorsResource,
+		NewCorelliumV1InstanceTraceResource,
+		NewCorelliumV1HookResource,
 	}
 }
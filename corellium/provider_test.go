@@ -1,11 +1,18 @@
 package corellium
 
 import (
+	"context"
 	"math/rand"
+	"net/http"
+	"os"
 	"strings"
+	"testing"
+	"time"
 
+	"github.com/aimoda/go-corellium-api-client"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"terraform-provider-corellium/corellium/pkg/mock"
 )
 
 const (
@@ -31,6 +38,49 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"corellium": providerserver.NewProtocol6WithError(New()),
 }
 
+// testAccMock is the mock Corellium API the acceptance tests run against when CORELLIUM_MOCK=1, or nil when they run
+// against the host of CORELLIUM_API_HOST.
+var testAccMock *mock.Server
+
+// TestMain starts the mock Corellium API, when CORELLIUM_MOCK=1, and points the provider to it.
+func TestMain(m *testing.M) {
+	if os.Getenv("CORELLIUM_MOCK") != "1" {
+		os.Exit(m.Run())
+	}
+
+	testAccMock = mock.NewServer(os.Getenv("CORELLIUM_API_TOKEN"))
+
+	// NOTICE: The mock changes the instances on each request instead of over time, so there is no need to wait.
+	pollWait = func(d time.Duration) time.Duration {
+		return d / 50
+	}
+
+	os.Setenv("CORELLIUM_API_HOST", testAccMock.Host())
+	os.Setenv("CORELLIUM_API_TOKEN", testAccMock.Token)
+
+	// NOTICE: The provider, and the API client, use the default transport, so it must trust the certificate of the mock.
+	http.DefaultTransport.(*http.Transport).TLSClientConfig = testAccMock.Client().Transport.(*http.Transport).TLSClientConfig
+
+	code := m.Run()
+
+	testAccMock.Close()
+	os.Exit(code)
+}
+
+// testAccClient returns a Corellium API client, and the context with the access token, to change the resources
+// outside of Terraform during acceptance testing, e.g. to delete a resource and check the drift.
+func testAccClient() (context.Context, *corellium.APIClient) {
+	configuration := corellium.NewConfiguration()
+	configuration.Host = "app.corellium.com"
+	if h := os.Getenv("CORELLIUM_API_HOST"); h != "" {
+		configuration.Host = h
+	}
+
+	auth := context.WithValue(context.Background(), corellium.ContextAccessToken, os.Getenv("CORELLIUM_API_TOKEN"))
+
+	return auth, corellium.NewAPIClient(configuration)
+}
+
 func generatePassword(passwordLength, minSpecialChar, minNum, minUpperCase int) string {
 	var (
 		lowerCharSet   = "abcdedfghijklmnopqrst"
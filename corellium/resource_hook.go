@@ -0,0 +1,543 @@
+package corellium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CorelliumV1HookResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1HookResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1HookResource{}
+)
+
+// NewCorelliumV1HookResource is a helper function to simplify the provider implementation.
+func NewCorelliumV1HookResource() resource.Resource {
+	return &CorelliumV1HookResource{}
+}
+
+// CorelliumV1HookResource is the resource implementation. It manages a hypervisor hook of an instance, what patches
+// the kernel of the instance at an address.
+type CorelliumV1HookResource struct {
+	client *corellium.APIClient
+}
+
+const (
+	// V1HookPatchTypeCsmfcc is the patch type of a hook written in C.
+	V1HookPatchTypeCsmfcc = "csmfcc"
+	// V1HookPatchTypeCsmfvm is the patch type of a hook written in the hypervisor assembly.
+	V1HookPatchTypeCsmfvm = "csmfvm"
+)
+
+const (
+	// V1HookApplyExecute executes the hooks of the instance again, while it is running.
+	V1HookApplyExecute = "execute"
+	// V1HookApplyReboot reboots the instance, so the hooks are applied while it boots.
+	V1HookApplyReboot = "reboot"
+	// V1HookApplyNone doesn't apply the hooks until the instance is rebooted or they are executed.
+	V1HookApplyNone = "none"
+)
+
+// V1HookModel maps the resource schema data.
+type V1HookModel struct {
+	Id       types.String `tfsdk:"id"`
+	Instance types.String `tfsdk:"instance"`
+	Label    types.String `tfsdk:"label"`
+	// Address is the kernel address, or symbol, the hook patches.
+	Address types.String `tfsdk:"address"`
+	// Patch is the body of the hook.
+	Patch types.String `tfsdk:"patch"`
+	// PatchType is the language of the patch.
+	// PatchType can assume the following values:
+	// csmfcc - C.
+	// csmfvm - Hypervisor assembly.
+	PatchType types.String `tfsdk:"patch_type"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	// Apply is how the changes to the hook are applied to the instance, when it is on.
+	// Apply can assume the following values:
+	// execute - Clear the hooks of the instance and execute them again.
+	// reboot - Reboot the instance.
+	// none - Don't apply the changes.
+	Apply     types.String `tfsdk:"apply"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+	// Timeouts is the time to wait for the instance to reboot, when the hook is applied with a reboot.
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// V1HookDefaultApplyTimeout is the default time to wait for the instance to reboot, when the hook is applied with a
+// reboot.
+const V1HookDefaultApplyTimeout = 15 * time.Minute
+
+// Metadata returns the resource type name.
+func (d *CorelliumV1HookResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1hook"
+}
+
+// Schema defines the schema for the resource.
+func (d *CorelliumV1HookResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Hook ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance ID",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Hook label",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"address": schema.StringAttribute{
+				Description: "Kernel address, or symbol, the hook patches",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"patch": schema.StringAttribute{
+				Description: "Hook body",
+				Required:    true,
+			},
+			"patch_type": schema.StringAttribute{
+				Description: "Patch type, csmfcc for C or csmfvm for the hypervisor assembly",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						V1HookPatchTypeCsmfcc,
+						V1HookPatchTypeCsmfvm,
+					),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Hook enabled. Default is true",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"apply": schema.StringAttribute{
+				Description: "How the changes are applied to the instance when it is on: execute, reboot or none. Default is execute",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(V1HookApplyExecute),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						V1HookApplyExecute,
+						V1HookApplyReboot,
+						V1HookApplyNone,
+					),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Hook creation date",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "Hook last update date",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (d *CorelliumV1HookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan V1HookModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, V1HookDefaultApplyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	hook, r, err := V1CreateHookManual(auth, d.client.GetConfig(), plan.Instance.ValueString(), newV1HookParametersManual(plan))
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("instance"),
+				"Instance not found",
+				"No instance was found with the id "+plan.Instance.ValueString(),
+			)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error creating hook", "An unexpected error was encountered trying to create the hook", apiErr)
+		return
+	}
+
+	plan.Id = types.StringValue(hook.GetIdentifier())
+	plan.CreatedAt = types.StringValue(hook.GetCreatedAt())
+	plan.UpdatedAt = types.StringValue(hook.GetUpdatedAt())
+
+	// NOTICE: The hook is saved before it is applied, so a failure to apply it doesn't leave it out of the state.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.apply(ctx, plan.Instance.ValueString(), plan.Apply.ValueString(), timeout)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *CorelliumV1HookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state V1HookModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	hook, r, err := d.client.HypervisorHooksApi.V1GetHookById(auth, state.Id.ValueString()).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The hook, or its instance, was deleted outside of Terraform, so it is removed from the state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Unable to read hook", "An unexpected error was encountered trying to read the hook", apiErr)
+		return
+	}
+
+	state.Instance = types.StringValue(hook.GetInstanceId())
+	state.Label = types.StringValue(hook.GetLabel())
+	state.Address = types.StringValue(hook.GetAddress())
+	state.Patch = types.StringValue(hook.GetPatch())
+	state.PatchType = types.StringValue(hook.GetPatchType())
+	state.Enabled = types.BoolValue(hook.GetEnabled())
+	state.CreatedAt = types.StringValue(hook.GetCreatedAt())
+	state.UpdatedAt = types.StringValue(hook.GetUpdatedAt())
+
+	// NOTICE: The API doesn't know how the hook is applied, so an imported hook gets the default.
+	if state.Apply.IsNull() {
+		state.Apply = types.StringValue(V1HookApplyExecute)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (d *CorelliumV1HookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state V1HookModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+	plan.CreatedAt = state.CreatedAt
+	plan.UpdatedAt = state.UpdatedAt
+
+	// NOTICE: A change of apply alone doesn't change the hook, so it is only saved.
+	if newV1HookParametersManual(plan) == newV1HookParametersManual(state) {
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, V1HookDefaultApplyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	hook, r, err := V1UpdateHookManual(auth, d.client.GetConfig(), plan.Id.ValueString(), newV1HookParametersManual(plan))
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		addAPIError(&resp.Diagnostics, "Error updating hook", "An unexpected error was encountered trying to update the hook", apiErr)
+		return
+	}
+
+	plan.UpdatedAt = types.StringValue(hook.GetUpdatedAt())
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.apply(ctx, plan.Instance.ValueString(), plan.Apply.ValueString(), timeout)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (d *CorelliumV1HookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state V1HookModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, V1HookDefaultApplyTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	r, err := d.client.HypervisorHooksApi.V1DeleteHook(auth, state.Id.ValueString()).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting hook", "An unexpected error was encountered trying to delete the hook", apiErr)
+		return
+	}
+
+	resp.Diagnostics.Append(d.apply(ctx, state.Instance.ValueString(), state.Apply.ValueString(), timeout)...)
+}
+
+// ImportState imports the hook into the Terraform state using its ID.
+func (d *CorelliumV1HookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (d *CorelliumV1HookResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
+
+// v1HookApplier serializes the application of the hooks of an instance.
+// Terraform creates, updates and deletes the hooks of an instance concurrently, so clearing and executing the hooks
+// for each of them would interleave, and a hook could be executed while another one reboots the instance. Instead,
+// each change of a hook waits for the application before it, and is skipped when an application that started after
+// the change already covered it.
+type v1HookApplier struct {
+	// mu is held while the hooks of the instance are applied.
+	mu sync.Mutex
+	// changes is the number of changes of the hooks of the instance.
+	changes uint64
+	// applied is the number of changes the last successful application covered, by apply mode.
+	applied map[string]uint64
+}
+
+var (
+	// v1HookAppliersMu guards v1HookAppliers and the changes of each applier.
+	v1HookAppliersMu sync.Mutex
+	// v1HookAppliers are the appliers of the hooks, by instance ID.
+	v1HookAppliers = map[string]*v1HookApplier{}
+)
+
+// v1HookChange records a change of the hooks of the instance, and returns its applier and the number of the change.
+func v1HookChange(instanceId string) (*v1HookApplier, uint64) {
+	v1HookAppliersMu.Lock()
+	defer v1HookAppliersMu.Unlock()
+
+	a, ok := v1HookAppliers[instanceId]
+	if !ok {
+		a = &v1HookApplier{applied: map[string]uint64{}}
+		v1HookAppliers[instanceId] = a
+	}
+
+	a.changes++
+	return a, a.changes
+}
+
+// covered returns the number of changes of the hooks of the instance made so far.
+func (a *v1HookApplier) covered() uint64 {
+	v1HookAppliersMu.Lock()
+	defer v1HookAppliersMu.Unlock()
+
+	return a.changes
+}
+
+// apply applies the hooks of the instance, when it is on. The hooks of an instance that isn't on are applied when it
+// boots, so there is nothing to do.
+func (d *CorelliumV1HookResource) apply(ctx context.Context, instanceId, mode string, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if mode == V1HookApplyNone {
+		return diags
+	}
+
+	a, change := v1HookChange(instanceId)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// NOTICE: A reboot applies every hook, so it covers the changes to execute too.
+	if a.applied[mode] >= change || a.applied[V1HookApplyReboot] >= change {
+		return diags
+	}
+
+	// NOTICE: The changes are made before they are recorded, so the application covers every change recorded before
+	// it starts.
+	covered := a.covered()
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	instance, r, err := d.client.InstancesApi.V1GetInstance(auth, instanceId).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			return diags
+		}
+
+		addAPIError(&diags, "Error applying hooks", "An unexpected error was encountered trying to read the instance", apiErr)
+		return diags
+	}
+
+	if string(instance.GetState()) != V1InstanceStateOn {
+		return diags
+	}
+
+	switch mode {
+	case V1HookApplyReboot:
+		r, err = d.client.InstancesApi.V1RebootInstance(auth, instanceId).Execute()
+		if err != nil {
+			addAPIError(&diags, "Error applying hooks", "An unexpected error was encountered trying to reboot the instance", NewAPIError(r, err))
+			return diags
+		}
+
+		if _, err := waitForInstanceTask(ctx, d.client, auth, instanceId, timeout); err != nil {
+			diags.AddError(
+				"Error applying hooks",
+				"Coudn't wait for the instance to reboot: "+err.Error(),
+			)
+			return diags
+		}
+	case V1HookApplyExecute:
+		// NOTICE: Executing the hooks doesn't undo the hooks that were executed before, e.g. the previous patch of
+		// the hook or a deleted hook, so they are cleared first.
+		r, err = d.client.HypervisorHooksApi.V1ClearHyperTraceHooks(auth, instanceId).Execute()
+		if err != nil {
+			addAPIError(&diags, "Error applying hooks", "An unexpected error was encountered trying to clear the hooks of the instance", NewAPIError(r, err))
+			return diags
+		}
+
+		r, err = d.client.HypervisorHooksApi.V1ExecuteHyperTraceHooks(auth, instanceId).Execute()
+		if err != nil {
+			addAPIError(&diags, "Error applying hooks", "An unexpected error was encountered trying to execute the hooks of the instance", NewAPIError(r, err))
+			return diags
+		}
+	}
+
+	a.applied[mode] = covered
+
+	return diags
+}
+
+// V1HookParametersManual are the parameters to create or update a hook. It has the fields of
+// corellium.V1CreateHookParameters, and the enabled field the API client doesn't have.
+type V1HookParametersManual struct {
+	Label     string `json:"label"`
+	Address   string `json:"address"`
+	Patch     string `json:"patch"`
+	PatchType string `json:"patchType"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// newV1HookParametersManual returns the parameters of the hook of the model.
+func newV1HookParametersManual(m V1HookModel) V1HookParametersManual {
+	return V1HookParametersManual{
+		Label:     m.Label.ValueString(),
+		Address:   m.Address.ValueString(),
+		Patch:     m.Patch.ValueString(),
+		PatchType: m.PatchType.ValueString(),
+		Enabled:   m.Enabled.ValueBool(),
+	}
+}
+
+// V1CreateHookManual creates a hook on the instance, with the enabled field the API client doesn't have.
+func V1CreateHookManual(ctx context.Context, cfg *corellium.Configuration, instanceId string, params V1HookParametersManual) (*corellium.Hook, *http.Response, error) {
+	return v1HookManual(ctx, cfg, http.MethodPost, "/api/v1/instances/"+url.PathEscape(instanceId)+"/hooks", params)
+}
+
+// V1UpdateHookManual updates the hook, with the enabled field the API client doesn't have.
+func V1UpdateHookManual(ctx context.Context, cfg *corellium.Configuration, hookId string, params V1HookParametersManual) (*corellium.Hook, *http.Response, error) {
+	return v1HookManual(ctx, cfg, http.MethodPut, "/api/v1/hooks/"+url.PathEscape(hookId), params)
+}
+
+// v1HookManual sends the parameters of the hook to the path.
+func v1HookManual(ctx context.Context, cfg *corellium.Configuration, method, path string, params V1HookParametersManual) (*corellium.Hook, *http.Response, error) {
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, resp, err := doManualRequest(ctx, cfg, method, path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var hook corellium.Hook
+	if err := json.Unmarshal(b, &hook); err != nil {
+		return nil, resp, err
+	}
+
+	return &hook, resp, nil
+}
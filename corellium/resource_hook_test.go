@@ -0,0 +1,193 @@
+package corellium
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCorelliumV1HookResource(t *testing.T) {
+	config := func(hook string) string {
+		return providerConfig + `
+        resource "corellium_v1project" "test" {
+            name = "` + t.Name() + `"
+            settings = {
+                version = 1
+                internet_access = true
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+
+        resource "corellium_v1instance" "test" {
+            name = "test-hook"
+            flavor = "ranchu"
+            project = corellium_v1project.test.id
+            os = "13.0.0"
+        }
+
+        resource "corellium_v1hook" "test" {
+            instance = corellium_v1instance.test.id
+            label = "zero-x0"
+            address = "0xffffff8008a1c000"
+            patch_type = "csmfcc"
+        ` + hook + `
+        }
+        `
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`patch = "cpu.x[0] = 0;"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("corellium_v1hook.test", "id"),
+					resource.TestCheckResourceAttrPair("corellium_v1hook.test", "instance", "corellium_v1instance.test", "id"),
+					resource.TestCheckResourceAttr("corellium_v1hook.test", "label", "zero-x0"),
+					resource.TestCheckResourceAttr("corellium_v1hook.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("corellium_v1hook.test", "apply", "execute"),
+					resource.TestCheckResourceAttrSet("corellium_v1hook.test", "created_at"),
+					testAccCheckExecutedHooks("corellium_v1instance.test", "cpu.x[0] = 0;"),
+				),
+			},
+			{
+				Config: config(`patch = "cpu.x[0] = 1;"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1hook.test", "patch", "cpu.x[0] = 1;"),
+					testAccCheckExecutedHooks("corellium_v1instance.test", "cpu.x[0] = 1;"),
+				),
+			},
+			{
+				Config: config(`
+                    patch = "cpu.x[0] = 1;"
+                    enabled = false
+                `),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1hook.test", "enabled", "false"),
+					testAccCheckExecutedHooks("corellium_v1instance.test"),
+				),
+			},
+			{
+				ResourceName:      "corellium_v1hook.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(`
+                    patch = "cpu.x[0] = 2;"
+                    apply = "reboot"
+                `),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1hook.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("corellium_v1hook.test", "apply", "reboot"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "on"),
+					testAccCheckExecutedHooks("corellium_v1instance.test", "cpu.x[0] = 2;"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1HookResource_instance(t *testing.T) {
+	config := func(patch string, labels ...string) string {
+		hooks := ""
+		for n, label := range labels {
+			apply := "execute"
+			if n == 0 {
+				apply = "reboot"
+			}
+
+			hooks += `
+            resource "corellium_v1hook" "` + label + `" {
+                instance = corellium_v1instance.test.id
+                label = "` + label + `"
+                address = "0xffffff8008a1c000"
+                patch_type = "csmfcc"
+                patch = "cpu.x[` + strconv.Itoa(n) + `] = ` + patch + `;"
+                apply = "` + apply + `"
+            }
+            `
+		}
+
+		return providerConfig + `
+        resource "corellium_v1project" "test" {
+            name = "` + t.Name() + `"
+            settings = {
+                version = 1
+                internet_access = true
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+
+        resource "corellium_v1instance" "test" {
+            name = "test-hook-instance"
+            flavor = "ranchu"
+            project = corellium_v1project.test.id
+            os = "13.0.0"
+            wait_for = "state"
+        }
+        ` + hooks
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("0", "a", "b", "c"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "on"),
+					testAccCheckExecutedHooks("corellium_v1instance.test", "cpu.x[0] = 0;", "cpu.x[1] = 0;", "cpu.x[2] = 0;"),
+				),
+			},
+			{
+				Config: config("1", "a", "b", "c"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "on"),
+					testAccCheckExecutedHooks("corellium_v1instance.test", "cpu.x[0] = 1;", "cpu.x[1] = 1;", "cpu.x[2] = 1;"),
+				),
+			},
+			{
+				Config: config("1", "a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExecutedHooks("corellium_v1instance.test", "cpu.x[0] = 1;", "cpu.x[1] = 1;"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckExecutedHooks checks the patches of the hooks executed on the instance of the mock, sorted by label.
+func testAccCheckExecutedHooks(name string, patches ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccMock == nil {
+			return nil
+		}
+
+		hooks := testAccMock.ExecutedHooks(s.RootModule().Resources[name].Primary.ID)
+		if len(hooks) != len(patches) {
+			return fmt.Errorf("expected %d executed hooks, got %d", len(patches), len(hooks))
+		}
+		for n, hook := range hooks {
+			if hook.GetPatch() != patches[n] {
+				return fmt.Errorf("expected executed hook %q, got %q", patches[n], hook.GetPatch())
+			}
+		}
+
+		return nil
+	}
+}
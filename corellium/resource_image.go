@@ -1,25 +1,41 @@
 package corellium
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"net/url"
 	"os"
+	"time"
 
 	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-corellium/corellium/pkg/api"
+	"terraform-provider-corellium/corellium/pkg/transport"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &CorelliumV1ImageResource{}
-	_ resource.ResourceWithConfigure = &CorelliumV1ImageResource{}
+	_ resource.Resource                = &CorelliumV1ImageResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1ImageResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1ImageResource{}
+	_ resource.ResourceWithModifyPlan  = &CorelliumV1ImageResource{}
 )
 
 // NewCorelliumV1ImageResource is a helper function to simplify the provider implementation.
@@ -41,10 +57,17 @@ type V1ImageModel struct {
 	// Name is the image name.
 	Name types.String `tfsdk:"name"`
 	// Type is the image type.
-	// Type can be one of the following: "fwbinary", "kernel", "devicetree", "ramdisk", "loaderfile", "sepfw", "seprom", "bootrom", "llb", "iboot", "ibootdata", "fwpackage", "partition", "backup"
+	// Type can be one of the V1ImageTypes.
 	Type types.String `tfsdk:"type"`
 	// Filename is the image filename or path.
 	Filename types.String `tfsdk:"filename"`
+	// Encoding is how the file is uploaded.
+	// Encoding can assume the following values:
+	// plain - The file is uploaded as it is.
+	// gzip - The file is compressed while it is uploaded.
+	Encoding types.String `tfsdk:"encoding"`
+	// SourceHash is the SHA256 hash of the file, what replaces the image when the file changes.
+	SourceHash types.String `tfsdk:"source_hash"`
 	// Encapsulated is the image encapsulated flag.
 	Encapsulated types.Bool `tfsdk:"encapsulated"`
 	// Uniqueid is the image unique ID.
@@ -55,8 +78,76 @@ type V1ImageModel struct {
 	Project types.String `tfsdk:"project"`
 	// CreatedAt is the image creation date.
 	CreatedAt types.String `tfsdk:"created_at"`
+	// Timeouts is the time to wait for the image to be uploaded and deleted.
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
+const (
+	// V1ImageDefaultCreateTimeout is the default time to wait for an image to be uploaded.
+	V1ImageDefaultCreateTimeout = 30 * time.Minute
+	// V1ImageDefaultDeleteTimeout is the default time to wait for an image to be deleted.
+	V1ImageDefaultDeleteTimeout = 5 * time.Minute
+)
+
+const (
+	// V1ImageEncodingPlain uploads the file as it is.
+	V1ImageEncodingPlain = "plain"
+	// V1ImageEncodingGzip compresses the file while it is uploaded.
+	V1ImageEncodingGzip = "gzip"
+)
+
+const (
+	// V1ImageTypeFwbinary is a firmware binary image.
+	V1ImageTypeFwbinary = "fwbinary"
+	// V1ImageTypeKernel is a kernel image, what an instance can boot with instead of the kernel of its firmware.
+	V1ImageTypeKernel = "kernel"
+	// V1ImageTypeDevicetree is a device tree image, what an instance can boot with instead of the device tree of its firmware.
+	V1ImageTypeDevicetree = "devicetree"
+	// V1ImageTypeRamdisk is a ramdisk image, what an instance can boot with instead of the ramdisk of its firmware.
+	V1ImageTypeRamdisk = "ramdisk"
+	// V1ImageTypeLoaderfile is a loader file image.
+	V1ImageTypeLoaderfile = "loaderfile"
+	// V1ImageTypeSepfw is a SEP firmware image.
+	V1ImageTypeSepfw = "sepfw"
+	// V1ImageTypeSeprom is a SEP ROM image.
+	V1ImageTypeSeprom = "seprom"
+	// V1ImageTypeBootrom is a BootROM image.
+	V1ImageTypeBootrom = "bootrom"
+	// V1ImageTypeLlb is a Low Level Bootloader image.
+	V1ImageTypeLlb = "llb"
+	// V1ImageTypeIbss is an iBSS image.
+	V1ImageTypeIbss = "ibss"
+	// V1ImageTypeIbec is an iBEC image.
+	V1ImageTypeIbec = "ibec"
+	// V1ImageTypeFwpackage is a firmware package image, e.g. an IPSW.
+	V1ImageTypeFwpackage = "fwpackage"
+	// V1ImageTypePartition is a partition image.
+	V1ImageTypePartition = "partition"
+	// V1ImageTypeBackup is a backup image.
+	V1ImageTypeBackup = "backup"
+)
+
+// V1ImageTypes are the image types the API accepts.
+var V1ImageTypes = []string{
+	V1ImageTypeFwbinary,
+	V1ImageTypeKernel,
+	V1ImageTypeDevicetree,
+	V1ImageTypeRamdisk,
+	V1ImageTypeLoaderfile,
+	V1ImageTypeSepfw,
+	V1ImageTypeSeprom,
+	V1ImageTypeBootrom,
+	V1ImageTypeLlb,
+	V1ImageTypeIbss,
+	V1ImageTypeIbec,
+	V1ImageTypeFwpackage,
+	V1ImageTypePartition,
+	V1ImageTypeBackup,
+}
+
+// V1ImageUploadProgressInterval is the time between the logs of the progress of an upload.
+const V1ImageUploadProgressInterval = 10 * time.Second
+
 // Metadata returns the resource type name.
 func (d *CorelliumV1ImageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_v1image"
@@ -66,7 +157,7 @@ func (d *CorelliumV1ImageResource) Metadata(_ context.Context, req resource.Meta
 }
 
 // Schema defines the schema for the resource.
-func (d *CorelliumV1ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (d *CorelliumV1ImageResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"status": schema.StringAttribute{
@@ -79,21 +170,43 @@ func (d *CorelliumV1ImageResource) Schema(_ context.Context, _ resource.SchemaRe
 			"name": schema.StringAttribute{
 				Description: "Image name",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Description: "Image type",
 				Required:    true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("fwbinary", "kernel", "devicetree", "ramdisk", "loaderfile", "sepfw", "seprom", "bootrom", "llb", "iboot", "ibootdata", "fwpackage", "partition", "backup"),
+					stringvalidator.OneOf(V1ImageTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"filename": schema.StringAttribute{
 				Description: "Image filename or path",
 				Required:    true,
 			},
+			"encoding": schema.StringAttribute{
+				Description: "Upload encoding, plain or gzip to compress the file while it is uploaded. Default is plain",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(V1ImageEncodingPlain),
+				Validators: []validator.String{
+					stringvalidator.OneOf(V1ImageEncodingPlain, V1ImageEncodingGzip),
+				},
+			},
+			"source_hash": schema.StringAttribute{
+				Description: "SHA256 hash of the file. The image is replaced when it changes",
+				Computed:    true,
+			},
 			"encapsulated": schema.BoolAttribute{
 				Description: "Image encapsulated flag",
 				Required:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
 			},
 			"unique_id": schema.StringAttribute{
 				Description: "Image unique ID",
@@ -106,12 +219,70 @@ func (d *CorelliumV1ImageResource) Schema(_ context.Context, _ resource.SchemaRe
 			"project": schema.StringAttribute{
 				Description: "Project ID",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Description: "Image creation date",
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan hashes the file, what fails the plan when it doesn't exist, and replaces the image when the file changed
+// since it was uploaded.
+func (d *CorelliumV1ImageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan V1ImageModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Filename.IsUnknown() {
+		return
+	}
+
+	sum, err := fileSHA256(plan.Filename.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("filename"),
+			"Unable to read image file",
+			"Coudn't hash the file "+plan.Filename.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_hash"), types.StringValue(sum))...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state V1ImageModel
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// NOTICE: An imported image doesn't have a hash, as the API doesn't return one, so it's kept as it is.
+	if !state.SourceHash.IsNull() && state.SourceHash.ValueString() != sum {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("source_hash"))
 	}
 }
 
@@ -125,7 +296,17 @@ func (d *CorelliumV1ImageResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	file, err := os.Open(plan.Filename.ValueString())
+	timeout, diags := plan.Timeouts.Create(ctx, V1ImageDefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	filename := plan.Filename.ValueString()
+	info, err := os.Stat(filename)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error opening image file",
@@ -136,50 +317,47 @@ func (d *CorelliumV1ImageResource) Create(ctx context.Context, req resource.Crea
 
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	// auth is the context with the access token, what is required by the API client.
+
+	// NOTICE: The image is created without its file, what the API client would read into memory, and the file is
+	// streamed to it afterwards.
 	image, r, err := d.client.ImagesApi.V1CreateImage(auth).
-		Encoding("plain").
+		Encoding(plan.Encoding.ValueString()).
 		Name(plan.Name.ValueString()).
 		Type_(plan.Type.ValueString()).
-		File(file).
 		Encapsulated(plan.Encapsulated.ValueBool()).
 		Project(plan.Project.ValueString()).
 		Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Error creating image",
-				"You don't have permission to create an image in this project.",
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error creating image", "You don't have permission to create an image in this project", apiErr)
 			return
 		}
 
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating image",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
+		addAPIError(&resp.Diagnostics, "Error creating image", "An unexpected error was encountered trying to create the image", apiErr)
+		return
+	}
 
-		resp.Diagnostics.AddError(
-			"Error creating image",
-			"An unexpected error was encountered trying to create the image:\n\n"+string(b),
-		)
+	setImageModel(&plan, image)
+
+	// NOTICE: The image is saved before its file is uploaded, so a failed upload leaves the image tainted in the state,
+	// and the next apply replaces it, deleting the image without its data.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	plan.Status = types.StringValue(image.GetStatus())
-	plan.Id = types.StringValue(image.GetId())
-	plan.Name = types.StringValue(image.GetName())
-	plan.Type = types.StringValue(image.GetType())
-	plan.Filename = types.StringValue(plan.Filename.ValueString())
-	plan.Encapsulated = types.BoolValue(plan.Encapsulated.ValueBool())
-	plan.Filename = types.StringValue(plan.Filename.ValueString())
-	plan.Uniqueid = types.StringValue(image.GetUniqueid())
-	plan.Size = types.NumberValue(big.NewFloat(float64(image.GetSize())))
-	plan.Project = types.StringValue(image.GetProject())
-	plan.CreatedAt = types.StringValue(image.GetCreatedAt().String())
+	upload := newImageUpload(ctx, filename, info.Size(), plan.Encoding.ValueString())
+	image, r, err = V1UploadImageDataManual(auth, d.client.GetConfig(), image.GetId(), upload.open, upload.length)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error creating image", "An unexpected error was encountered trying to upload the image file, the image will be replaced by the next apply", NewAPIError(r, err))
+		return
+	}
+
+	tflog.Info(ctx, "Uploaded the image", map[string]interface{}{"image": image.GetId(), "bytes": info.Size()})
+
+	setImageModel(&plan, image)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -188,6 +366,18 @@ func (d *CorelliumV1ImageResource) Create(ctx context.Context, req resource.Crea
 	}
 }
 
+// setImageModel sets the attributes of the model returned by the API.
+func setImageModel(m *V1ImageModel, image *corellium.Image) {
+	m.Status = types.StringValue(image.GetStatus())
+	m.Id = types.StringValue(image.GetId())
+	m.Name = types.StringValue(image.GetName())
+	m.Type = types.StringValue(image.GetType())
+	m.Uniqueid = types.StringValue(image.GetUniqueid())
+	m.Size = types.NumberValue(big.NewFloat(float64(image.GetSize())))
+	m.Project = types.StringValue(image.GetProject())
+	m.CreatedAt = types.StringValue(image.GetCreatedAt().String())
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *CorelliumV1ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state V1ImageModel
@@ -202,18 +392,14 @@ func (d *CorelliumV1ImageResource) Read(ctx context.Context, req resource.ReadRe
 	// auth is the context with the access token, what is required by the API client.
 	image, r, err := d.client.ImagesApi.V1GetImage(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error reading image",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The image was deleted outside of Terraform, so it's removed from the state to be created again.
+			resp.State.RemoveResource(ctx)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Unable to read image",
-			"An unexpected error was encountered trying to read the image:\n\n"+string(b))
+		addAPIError(&resp.Diagnostics, "Unable to read image", "An unexpected error was encountered trying to read the image", apiErr)
 		return
 	}
 
@@ -221,8 +407,15 @@ func (d *CorelliumV1ImageResource) Read(ctx context.Context, req resource.ReadRe
 	state.Id = types.StringValue(image.GetId())
 	state.Name = types.StringValue(image.GetName())
 	state.Type = types.StringValue(image.GetType())
-	state.Filename = types.StringValue(state.Filename.ValueString())
+	// NOTICE: The API doesn't return the local path of the uploaded file nor the encapsulated flag, so, when the image
+	// is imported, the filename falls back to the one stored by the API and the encapsulated flag to false.
+	if state.Filename.IsNull() {
+		state.Filename = types.StringValue(image.GetFilename())
+	}
 	state.Encapsulated = types.BoolValue(state.Encapsulated.ValueBool())
+	if state.Encoding.IsNull() {
+		state.Encoding = types.StringValue(V1ImageEncodingPlain)
+	}
 	state.Uniqueid = types.StringValue(image.GetUniqueid())
 	state.Size = types.NumberValue(big.NewFloat(float64(image.GetSize())))
 	state.Project = types.StringValue(image.GetProject())
@@ -237,8 +430,33 @@ func (d *CorelliumV1ImageResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
+// NOTICE: An image can't be updated, and a new file replaces it, so only the attributes that don't change the uploaded
+// image are saved, e.g. the hash of an imported image or the encoding.
 func (d *CorelliumV1ImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// NOTICE: In this case, image cannot be updated, so we just return the current state.
+	var plan, state V1ImageModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Filename = plan.Filename
+	state.Encoding = plan.Encoding
+	state.SourceHash = plan.SourceHash
+	state.Timeouts = plan.Timeouts
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -251,25 +469,28 @@ func (d *CorelliumV1ImageResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, V1ImageDefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	r, err := d.client.ImagesApi.V1DeleteImage(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting image",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Unable to delete image",
-			"An unexpected error was encountered trying to delete the image:\n\n"+string(b))
+		addAPIError(&resp.Diagnostics, "Unable to delete image", "An unexpected error was encountered trying to delete the image", NewAPIError(r, err))
 		return
 	}
 }
 
+// ImportState imports an existing image into the Terraform state using its ID.
+func (d *CorelliumV1ImageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
 // Configure adds the provider configured client to the resource.
 func (d *CorelliumV1ImageResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -278,3 +499,132 @@ func (d *CorelliumV1ImageResource) Configure(_ context.Context, req resource.Con
 
 	d.client = req.ProviderData.(*corellium.APIClient)
 }
+
+// imageUpload is the file of an image to upload, what is read from the start on each attempt.
+type imageUpload struct {
+	ctx      context.Context
+	filename string
+	size     int64
+	encoding string
+	// length is the length of the request body, or -1 when it isn't known until the file is compressed.
+	length int64
+	// attempts is the number of times the file was opened.
+	attempts int
+}
+
+// newImageUpload returns the upload of the file, of the size, with the encoding.
+func newImageUpload(ctx context.Context, filename string, size int64, encoding string) *imageUpload {
+	u := &imageUpload{ctx: ctx, filename: filename, size: size, encoding: encoding, length: size}
+	if encoding == V1ImageEncodingGzip {
+		u.length = -1
+	}
+
+	return u
+}
+
+// open opens the file from the start, compressing it when the encoding is gzip, and logs the progress of the upload
+// while it is read.
+func (u *imageUpload) open() (io.ReadCloser, error) {
+	u.attempts++
+	if u.attempts > 1 {
+		// NOTICE: The API doesn't upload part of an image, so a failed upload is sent again from the start.
+		tflog.Warn(u.ctx, "Retrying the upload of the image from the start", map[string]interface{}{"filename": u.filename, "attempt": u.attempts})
+	}
+
+	file, err := os.Open(u.filename)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &imageUploadProgress{ctx: u.ctx, r: file, total: u.size, logged: time.Now()}
+	if u.encoding != V1ImageEncodingGzip {
+		return struct {
+			io.Reader
+			io.Closer
+		}{progress, file}, nil
+	}
+
+	// NOTICE: The file is compressed while it is sent, so it's never held in memory.
+	body, w := io.Pipe()
+	go func() {
+		defer file.Close()
+
+		gz := gzip.NewWriter(w)
+		if _, err := io.Copy(gz, progress); err != nil {
+			w.CloseWithError(err)
+			return
+		}
+
+		w.CloseWithError(gz.Close())
+	}()
+
+	return body, nil
+}
+
+// imageUploadProgress logs the bytes read from the file of an image every V1ImageUploadProgressInterval.
+type imageUploadProgress struct {
+	ctx    context.Context
+	r      io.Reader
+	total  int64
+	read   int64
+	logged time.Time
+}
+
+// Read implements the io.Reader interface.
+func (p *imageUploadProgress) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+
+	if time.Since(p.logged) >= V1ImageUploadProgressInterval || (err == io.EOF && p.read == p.total) {
+		p.logged = time.Now()
+
+		percent := 100.0
+		if p.total > 0 {
+			percent = float64(p.read) * 100 / float64(p.total)
+		}
+
+		tflog.Info(p.ctx, "Uploading the image", map[string]interface{}{
+			"bytes":   p.read,
+			"total":   p.total,
+			"percent": fmt.Sprintf("%.1f", percent),
+		})
+	}
+
+	return n, err
+}
+
+// V1UploadImageDataManual uploads the data of the image from the body opened by open, what the API client would read
+// into memory. A length of -1 means the length of the body isn't known.
+//
+// The upload replaces the data of the image, so it's retried on a failure like an idempotent request, opening the
+// body again, with the retries of the provider.
+//
+// NOTICE: The endpoint only accepts the whole file, without an offset or a range, so the data can't be sent in chunks,
+// nor an interrupted upload be resumed.
+func V1UploadImageDataManual(ctx context.Context, cfg *corellium.Configuration, imageId string, open func() (io.ReadCloser, error), length int64) (*corellium.Image, *http.Response, error) {
+	body, err := open()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := newManualRequest(transport.WithIdempotent(ctx), cfg, http.MethodPost, "/api/v1/images/"+url.PathEscape(imageId), body)
+	if err != nil {
+		body.Close()
+		return nil, nil, err
+	}
+	req.ContentLength = length
+	req.GetBody = open
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	b, resp, err := sendManualRequest(cfg, req)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var image corellium.Image
+	if err := json.Unmarshal(b, &image); err != nil {
+		return nil, resp, err
+	}
+
+	return &image, resp, nil
+}
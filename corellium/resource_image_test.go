@@ -1,11 +1,17 @@
 package corellium
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccCorelliumV1ImageResource(t *testing.T) {
@@ -25,7 +31,7 @@ func TestAccCorelliumV1ImageResource(t *testing.T) {
 
 	projectConfig := `
     resource "corellium_v1project" "test" {
-        name = "test"
+        name = "` + t.Name() + `"
         settings = {
             version = 1
             internet_access = false
@@ -40,7 +46,7 @@ func TestAccCorelliumV1ImageResource(t *testing.T) {
     }
     `
 
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		PreCheck:                 preCheck,
 		Steps: []resource.TestStep{
@@ -62,6 +68,214 @@ func TestAccCorelliumV1ImageResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("corellium_v1image.test", "project"),
 				),
 			},
+			{
+				ResourceName:            "corellium_v1image.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"filename", "encapsulated", "source_hash"},
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1ImageResource_source_hash(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "kernel.bin")
+	write := func(content string) {
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("kernel v1")
+
+	config := func(name, filename string) string {
+		return providerConfig + fmt.Sprintf(`
+        resource "corellium_v1project" "test" {
+            name = "`+t.Name()+`"
+            settings = {
+                version = 1
+                internet_access = false
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+
+        resource "corellium_v1image" "test" {
+            name = %q
+            type = "kernel"
+            filename = %q
+            encoding = "gzip"
+            encapsulated = false
+            project = corellium_v1project.test.id
+        }
+        `, name, filename)
+	}
+
+	var id string
+	checkReplaced := func(replaced bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			prior := id
+			id = s.RootModule().Resources["corellium_v1image.test"].Primary.ID
+			if replaced == (prior == id) {
+				return fmt.Errorf("expected image replaced %t, got ID %s, was %s", replaced, id, prior)
+			}
+
+			return nil
+		}
+	}
+
+	checkContent := func(content string) resource.TestCheckFunc {
+		checks := []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("corellium_v1image.test", "source_hash", fmt.Sprintf("%x", sha256.Sum256([]byte(content)))),
+			resource.TestCheckResourceAttr("corellium_v1image.test", "encoding", "gzip"),
+		}
+		if testAccMock != nil {
+			// The mock stores the file decompressed, so its unique ID is the hash of the file as it was.
+			checks = append(checks,
+				resource.TestCheckResourceAttr("corellium_v1image.test", "unique_id", fmt.Sprintf("%x", sha256.Sum256([]byte(content)))),
+				resource.TestCheckResourceAttr("corellium_v1image.test", "size", fmt.Sprint(len(content))),
+			)
+		}
+
+		return resource.ComposeTestCheckFunc(checks...)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("test", filename),
+				Check: resource.ComposeTestCheckFunc(
+					checkContent("kernel v1"),
+					checkReplaced(true),
+				),
+			},
+			{
+				PreConfig: func() {
+					write("kernel v2")
+				},
+				Config: config("test", filename),
+				Check: resource.ComposeTestCheckFunc(
+					checkContent("kernel v2"),
+					checkReplaced(true),
+				),
+			},
+			{
+				// The API can't rename an image, so it is replaced.
+				Config: config("renamed", filename),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1image.test", "name", "renamed"),
+					checkContent("kernel v2"),
+					checkReplaced(true),
+				),
+			},
+			{
+				Config:      config("renamed", filepath.Join(t.TempDir(), "missing.bin")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unable to read image file"),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1ImageResource_upload(t *testing.T) {
+	if testAccMock == nil {
+		t.Skip("The tests can't interrupt an upload to the real API.")
+	}
+
+	filename := filepath.Join(t.TempDir(), "backup.bin")
+	content := strings.Repeat("backup", 1<<16)
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(names ...string) string {
+		config := `
+        provider "corellium" {
+            token = ""
+            max_retries = 1
+        }
+
+        resource "corellium_v1project" "test" {
+            name = "` + t.Name() + `"
+            settings = {
+                version = 1
+                internet_access = false
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+        `
+		for _, name := range names {
+			config += fmt.Sprintf(`
+            resource "corellium_v1image" %[1]q {
+                name = %[1]q
+                type = "backup"
+                filename = %[2]q
+                encapsulated = false
+                project = corellium_v1project.test.id
+            }
+            `, name, filename)
+		}
+
+		return config
+	}
+
+	// checkImages checks the images of the project in the mock, as a replaced image is deleted.
+	checkImages := func(n int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			images := testAccMock.Images(s.RootModule().Resources["corellium_v1project.test"].Primary.ID)
+			if len(images) != n {
+				return fmt.Errorf("expected %d images, got %d", n, len(images))
+			}
+
+			return nil
+		}
+	}
+
+	// NOTICE: The mock fails the uploads of every image, so the test doesn't run in parallel with the others.
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The upload fails once and it is retried.
+				PreConfig: func() {
+					testAccMock.FailImageUploads(1)
+				},
+				Config: config("retried"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1image.retried", "status", "active"),
+					resource.TestCheckResourceAttr("corellium_v1image.retried", "size", fmt.Sprint(len(content))),
+					resource.TestCheckResourceAttr("corellium_v1image.retried", "unique_id", fmt.Sprintf("%x", sha256.Sum256([]byte(content)))),
+					checkImages(1),
+				),
+			},
+			{
+				// The upload fails more times than it is retried, so the image is left incomplete and tainted.
+				PreConfig: func() {
+					testAccMock.FailImageUploads(2)
+				},
+				Config:      config("retried", "replaced"),
+				ExpectError: regexp.MustCompile("Error creating image"),
+			},
+			{
+				// The next apply replaces the incomplete image, uploading the file to a new one.
+				Config: config("retried", "replaced"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1image.replaced", "status", "active"),
+					resource.TestCheckResourceAttr("corellium_v1image.replaced", "size", fmt.Sprint(len(content))),
+					checkImages(2),
+				),
+			},
 		},
 	})
 }
@@ -81,7 +295,7 @@ func TestAccCorelliumV1ImageResource_non_enterprise(t *testing.T) {
 		}
 	}
 
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		PreCheck:                 preCheck,
 		Steps: []resource.TestStep{
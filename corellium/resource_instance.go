@@ -1,15 +1,32 @@
 package corellium
 
 import (
+	"bytes"
 	"context"
-	"io"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -18,8 +35,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &CorelliumV1InstanceResource{}
-	_ resource.ResourceWithConfigure = &CorelliumV1InstanceResource{}
+	_ resource.Resource                = &CorelliumV1InstanceResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1InstanceResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1InstanceResource{}
+	_ resource.ResourceWithModifyPlan  = &CorelliumV1InstanceResource{}
 )
 
 // NewCorelliumV1InstanceResource is a helper function to simplify the provider implementation.
@@ -32,9 +51,38 @@ type CorelliumV1InstanceResource struct {
 	client *corellium.APIClient
 }
 
+// V1InstanceVPNPortModel is a port the instance VPN proxies, or listens on.
+type V1InstanceVPNPortModel struct {
+	// DevicePort is the port on the device.
+	DevicePort types.Int64 `tfsdk:"device_port"`
+	// RouterPort is the port on the router of the project.
+	RouterPort types.Int64 `tfsdk:"router_port"`
+	// Expose is true when the port is exposed to the external interface.
+	Expose types.Bool `tfsdk:"expose"`
+	// FirstAvailable is true when the first available port is used if the device port isn't available.
+	FirstAvailable types.Bool `tfsdk:"first_available"`
+}
+
 type V1InstanceVPNModel struct {
-	Proxy     types.Map `tfsdk:"proxy"`
-	Listeners types.Map `tfsdk:"listeners"`
+	Proxy     []V1InstanceVPNPortModel `tfsdk:"proxy"`
+	Listeners []V1InstanceVPNPortModel `tfsdk:"listeners"`
+}
+
+// V1InstancePortForwardModel is a port of the device forwarded to the project router.
+type V1InstancePortForwardModel struct {
+	// DevicePort is the port on the device.
+	DevicePort types.Int64 `tfsdk:"device_port"`
+	// RouterPort is the port on the project router. When it isn't set, the first available port is used.
+	RouterPort types.Int64 `tfsdk:"router_port"`
+	// Expose is true when the port is exposed to the external interface.
+	Expose types.Bool `tfsdk:"expose"`
+}
+
+// v1InstancePortForwardAttrTypes are the attribute types of a forwarded port.
+var v1InstancePortForwardAttrTypes = map[string]attr.Type{
+	"device_port": types.Int64Type,
+	"router_port": types.Int64Type,
+	"expose":      types.BoolType,
 }
 
 type V1InstanceServicesModel struct {
@@ -82,6 +130,15 @@ type V1InstanceBootOptionsModel struct {
 	// llb-jailbreak: Patch LLB to disable signature checks
 	// rom-jailbreak: Patch BootROM to disable signature checks
 	AdditionalTags types.List `tfsdk:"additional_tags"`
+	// Screen is the screen size of the instance, e.g. 720x1280:280 (width x height : dpi).
+	Screen types.String `tfsdk:"screen"`
+}
+
+type V1InstanceDeviceModel struct {
+	Type   types.String `tfsdk:"type"`
+	Name   types.String `tfsdk:"name"`
+	Flavor types.String `tfsdk:"flavor"`
+	Model  types.String `tfsdk:"model"`
 }
 
 const (
@@ -95,6 +152,14 @@ const (
 	V1InstanceStateCreating = "creating"
 	// V1InstanceStateDeleting is the state of the instance when it is being deleted.
 	V1InstanceStateDeleting = "deleting"
+	// V1InstanceStateBooting is the state of the instance when it is booting.
+	V1InstanceStateBooting = "booting"
+	// V1InstanceStateRebooting is the state of the instance when it is rebooting.
+	V1InstanceStateRebooting = "rebooting"
+	// V1InstanceStateRestoring is the state of the instance when a snapshot is being restored.
+	V1InstanceStateRestoring = "restoring"
+	// V1InstanceStateError is the state of the instance when it has failed.
+	V1InstanceStateError = "error"
 )
 
 const (
@@ -181,8 +246,13 @@ type V1InstanceModel struct {
 	ServiceIP   types.String                `tfsdk:"service_ip"`
 	WifiIP      types.String                `tfsdk:"wifi_ip"`
 	SecondaryIP types.String                `tfsdk:"secondary_ip"`
-	// Services    *V1InstanceServicesModel    `tfsdk:"services"` // TODO: find the right type for this.
-	Panicked types.Bool `tfsdk:"panicked"`
+	Services    *V1InstanceServicesModel    `tfsdk:"services"`
+	// PortForward is the set of ports of the device forwarded to the project router. When it is set, it replaces the
+	// ports the instance proxies.
+	PortForward types.Set `tfsdk:"port_forward"`
+	// PortForwardEndpoints are the endpoints of the proxied ports, the service IP and the router port, by device port.
+	PortForwardEndpoints types.Map  `tfsdk:"port_forward_endpoints"`
+	Panicked             types.Bool `tfsdk:"panicked"`
 	// Created is the time the instance was created.
 	Created   types.String `tfsdk:"created"`
 	Model     types.String `tfsdk:"model"`
@@ -200,11 +270,33 @@ type V1InstanceModel struct {
 	Patches types.List `tfsdk:"patches"`
 	// CreatedBy is the user who created the instance.
 	CreatedBy *V1InstanceCreatedByModel `tfsdk:"created_by"`
+	// OSBuild is the build of the operating system to run on the instance.
+	OSBuild types.String `tfsdk:"osbuild"`
+	// IPSW is the URL or image id of the firmware package to create the instance from.
+	IPSW types.String `tfsdk:"ipsw"`
+	// Snapshot is the id of the snapshot to clone the instance from.
+	Snapshot types.String `tfsdk:"snapshot"`
+	// CustomKernel is the id of a kernel image of the project to boot the instance with.
+	CustomKernel types.String `tfsdk:"custom_kernel"`
+	// CustomRamdisk is the id of a ramdisk image of the project to boot the instance with.
+	CustomRamdisk types.String `tfsdk:"custom_ramdisk"`
+	// CustomDevicetree is the id of a devicetree image of the project to boot the instance with.
+	CustomDevicetree types.String `tfsdk:"custom_devicetree"`
+	// Encrypt is a boolean that indicates if the instance should be encrypted.
+	Encrypt types.Bool `tfsdk:"encrypt"`
+	// Device is the device model to create the instance with.
+	Device *V1InstanceDeviceModel `tfsdk:"device"`
 	// WaitForReady is a boolean that indicates if the resource should wait for the instance to be ready.
 	WaitForReady types.Bool `tfsdk:"wait_for_ready"`
-	// WaitForReadyTimeout is the timeout in seconds to wait for the instance to be ready.
-	// WaitForReadyTimeout is a amount in seconds.
+	// WaitForReadyTimeout is the time in seconds to wait for the instance to be ready, what is deprecated by the create
+	// timeout.
 	WaitForReadyTimeout types.Int64 `tfsdk:"wait_for_ready_timeout"`
+	// WaitFor is what the resource waits for when the instance is created or turned on: the instance state, or also
+	// the agent of the instance to be ready.
+	WaitFor types.String `tfsdk:"wait_for"`
+	// Timeouts is the time to wait for the instance to be ready after it is created, to reach a new state after it
+	// is updated, and to be deleted.
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -216,7 +308,7 @@ func (d *CorelliumV1InstanceResource) Metadata(_ context.Context, req resource.M
 }
 
 // Schema defines the schema for the resource.
-func (d *CorelliumV1InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (d *CorelliumV1InstanceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -275,42 +367,115 @@ func (d *CorelliumV1InstanceResource) Schema(_ context.Context, _ resource.Schem
 			},
 			"boot_options": schema.SingleNestedAttribute{
 				Description: "Instance boot options",
+				Optional:    true,
 				Computed:    true,
 				Default:     nil,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				// NOTICE: The boot options are only sent when the instance is created, so changing any of them
+				// replaces the instance. The plan modifiers are set on each attribute, instead of on the object,
+				// so the attributes computed by the API don't force a replacement.
 				Attributes: map[string]schema.Attribute{
 					"boot_args": schema.StringAttribute{
 						Description: "Instance boot args",
+						Optional:    true,
 						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"restore_boot_args": schema.StringAttribute{
 						Description: "Instance restore boot args",
+						Optional:    true,
 						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"udid": schema.StringAttribute{
 						Description: "Instance boot options udid",
+						Optional:    true,
 						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"ecid": schema.StringAttribute{
 						Description: "Instance boot options ecid",
+						Optional:    true,
 						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"random_seed": schema.StringAttribute{
 						Description: "Instance boot options random seed",
+						Optional:    true,
 						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"pac": schema.BoolAttribute{
 						Description: "Instance boot options pac",
+						Optional:    true,
 						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+							boolplanmodifier.RequiresReplace(),
+						},
 					},
 					"aprr": schema.BoolAttribute{
 						Description: "Instance boot options aprr",
+						Optional:    true,
 						Computed:    true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+							boolplanmodifier.RequiresReplace(),
+						},
 					},
 					"additional_tags": schema.ListAttribute{
-						// TODO: add validation to this list.
 						Description: "Instance boot options additional tags",
 						ElementType: types.StringType,
+						Optional:    true,
 						Computed:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(
+								stringvalidator.OneOf(
+									"kalloc",
+									"gpu",
+									"no-keyboard",
+									"nodevmode",
+									"sep-cons-ext",
+									"iboot-jailbreak",
+									"llb-jailbreak",
+									"rom-jailbreak",
+								),
+							),
+						},
+						PlanModifiers: []planmodifier.List{
+							listplanmodifier.UseStateForUnknown(),
+							listplanmodifier.RequiresReplace(),
+						},
+					},
+					"screen": schema.StringAttribute{
+						Description: "Instance boot options screen size",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(
+								regexp.MustCompile(`^\d+x\d+(:\d+)?$`),
+								"must be in the format WIDTHxHEIGHT or WIDTHxHEIGHT:DPI, e.g. 720x1280:280",
+							),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 				},
 			},
@@ -326,7 +491,7 @@ func (d *CorelliumV1InstanceResource) Schema(_ context.Context, _ resource.Schem
 				Description: "Instance secondary ip",
 				Computed:    true,
 			},
-			/*"services": schema.SingleNestedAttribute{ // TODO: find the right type for this.
+			"services": schema.SingleNestedAttribute{
 				Description: "Instance services",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
@@ -334,16 +499,51 @@ func (d *CorelliumV1InstanceResource) Schema(_ context.Context, _ resource.Schem
 						Description: "Instance services vpn",
 						Computed:    true,
 						Attributes: map[string]schema.Attribute{
-							"proxy": schema.MapAttribute{
-								Computed: true,
+							"proxy": schema.ListNestedAttribute{
+								Description:  "Instance vpn proxied ports",
+								Computed:     true,
+								NestedObject: schema.NestedAttributeObject{Attributes: v1InstanceVPNPortAttributes()},
+							},
+							"listeners": schema.ListNestedAttribute{
+								Description:  "Instance vpn listened ports",
+								Computed:     true,
+								NestedObject: schema.NestedAttributeObject{Attributes: v1InstanceVPNPortAttributes()},
+							},
+						},
+					},
+				},
+			},
+			"port_forward": schema.SetNestedAttribute{
+				Description: "Instance ports forwarded to the project router, what replace the proxied ports when it is set",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device_port": schema.Int64Attribute{
+							Description: "Port on the device",
+							Required:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
 							},
-							"listeners": schema.MapAttribute{
-								Computed: true,
+						},
+						"router_port": schema.Int64Attribute{
+							Description: "Port on the project router. Default is the first available port",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
 							},
 						},
+						"expose": schema.BoolAttribute{
+							Description: "Port exposed to the external interface. Default is false",
+							Optional:    true,
+						},
 					},
 				},
-			},*/
+			},
+			"port_forward_endpoints": schema.MapAttribute{
+				Description: "Instance proxied port endpoints, by device port",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"panicked": schema.BoolAttribute{
 				Description: "Instance panicked",
 				Computed:    true,
@@ -379,7 +579,8 @@ func (d *CorelliumV1InstanceResource) Schema(_ context.Context, _ resource.Schem
 				},
 			},
 			"netmon": schema.SingleNestedAttribute{
-				Description: "Instance agent",
+				Description: "Instance network monitor",
+				Optional:    true,
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
 					"hash": schema.StringAttribute{
@@ -391,7 +592,8 @@ func (d *CorelliumV1InstanceResource) Schema(_ context.Context, _ resource.Schem
 						Computed:    true,
 					},
 					"enabled": schema.BoolAttribute{
-						Description: "Instance netmon enabled",
+						Description: "Instance netmon enabled, what starts or stops the network monitor when it is set",
+						Optional:    true,
 						Computed:    true,
 					},
 				},
@@ -406,8 +608,18 @@ func (d *CorelliumV1InstanceResource) Schema(_ context.Context, _ resource.Schem
 			},
 			"patches": schema.ListAttribute{
 				Description: "Instance patches",
+				Optional:    true,
 				Computed:    true,
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.OneOf("jailbroken", "nonjailbroken", "corelliumd"),
+					),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+					listplanmodifier.RequiresReplace(),
+				},
 			},
 			"created_by": schema.SingleNestedAttribute{
 				Description: "Instance created by",
@@ -431,20 +643,169 @@ func (d *CorelliumV1InstanceResource) Schema(_ context.Context, _ resource.Schem
 					},
 				},
 			},
+			"osbuild": schema.StringAttribute{
+				Description: "Instance os build",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipsw": schema.StringAttribute{
+				Description: "Instance firmware package URL or image id",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot": schema.StringAttribute{
+				Description: "Instance snapshot id to clone from",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"custom_kernel": schema.StringAttribute{
+				Description: "Instance custom kernel, the id of a kernel image of the instance project",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"custom_ramdisk": schema.StringAttribute{
+				Description: "Instance custom ramdisk, the id of a ramdisk image of the instance project",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"custom_devicetree": schema.StringAttribute{
+				Description: "Instance custom devicetree, the id of a devicetree image of the instance project",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encrypt": schema.BoolAttribute{
+				Description: "Instance encryption",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"device": schema.SingleNestedAttribute{
+				Description: "Instance device model",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Description: "Instance device type",
+						Required:    true,
+					},
+					"name": schema.StringAttribute{
+						Description: "Instance device name",
+						Required:    true,
+					},
+					"flavor": schema.StringAttribute{
+						Description: "Instance device flavor",
+						Required:    true,
+					},
+					"model": schema.StringAttribute{
+						Description: "Instance device model",
+						Required:    true,
+					},
+				},
+			},
 			"wait_for_ready": schema.BoolAttribute{
 				Description: "Wait for ready",
 				Optional:    true,
 			},
 			"wait_for_ready_timeout": schema.Int64Attribute{
-				Description: "Wait for ready timeout",
+				Description:        "Wait for ready timeout, in seconds",
+				Optional:           true,
+				DeprecationMessage: "Use timeouts.create instead, what takes precedence when both are set.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"wait_for": schema.StringAttribute{
+				Description: "Wait for the instance state, or also for the instance agent, to be ready when it is created or turned on",
 				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(V1InstanceWaitForState, V1InstanceWaitForAgent),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
-// WaitForReadyTimeout is the default timeout for waiting for an instance to be ready.
-const WaitForReadyTimeout = 900
+const (
+	// V1InstanceWaitForState waits for the instance to reach its state.
+	V1InstanceWaitForState = "state"
+	// V1InstanceWaitForAgent waits for the instance to reach its state, and for its agent to be ready.
+	V1InstanceWaitForAgent = "agent"
+)
+
+const (
+	// V1InstanceDefaultCreateTimeout is the default time to wait for an instance to be ready after it is created.
+	V1InstanceDefaultCreateTimeout = 15 * time.Minute
+	// V1InstanceDefaultUpdateTimeout is the default time to wait for an instance to reach a new state.
+	V1InstanceDefaultUpdateTimeout = 15 * time.Minute
+	// V1InstanceDefaultDeleteTimeout is the default time to wait for an instance to be deleted.
+	V1InstanceDefaultDeleteTimeout = 5 * time.Minute
+)
+
+// ModifyPlan checks the custom images the instance boots with are of the right type, and of the instance project.
+func (d *CorelliumV1InstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// NOTICE: The attributes are read one by one, as the nested objects computed by the API are unknown in the plan.
+	var project types.String
+
+	diags := req.Plan.GetAttribute(ctx, path.Root("project"), &project)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	// auth is the context with the access token, what is required by the API client.
+	for _, image := range v1InstanceCustomImages {
+		var id, prior types.String
+
+		diags = req.Plan.GetAttribute(ctx, image.path, &id)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !req.State.Raw.IsNull() {
+			diags = req.State.GetAttribute(ctx, image.path, &prior)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		// NOTICE: An image created in the same apply is unknown until then, and it's checked when Terraform plans
+		// the instance again before creating it.
+		if id.IsNull() || id.IsUnknown() || id.Equal(prior) {
+			continue
+		}
+
+		resp.Diagnostics.Append(d.checkCustomImage(auth, image, id.ValueString(), project)...)
+	}
+}
 
 // Create creates the resource and sets the initial Terraform state.
 func (d *CorelliumV1InstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -461,19 +822,7 @@ func (d *CorelliumV1InstanceResource) Create(ctx context.Context, req resource.C
 	if plan.Project.IsNull() || plan.Project.IsUnknown() {
 		projects, r, err := d.client.ProjectsApi.V1GetProjects(auth).Execute()
 		if err != nil {
-			b, err := io.ReadAll(r.Body)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error get default project",
-					"Coudn't read the response body from project name check: "+err.Error(),
-				)
-				return
-			}
-
-			resp.Diagnostics.AddError(
-				"Error get default project",
-				"Couldn't get projects to create instance: "+string(b),
-			)
+			addAPIError(&resp.Diagnostics, "Error get default project", "Couldn't get projects to create instance", NewAPIError(r, err))
 			return
 		}
 
@@ -489,29 +838,124 @@ func (d *CorelliumV1InstanceResource) Create(ctx context.Context, req resource.C
 
 	i := corellium.NewInstanceCreateOptions(plan.Flavor.ValueString(), plan.Project.ValueString(), plan.OS.ValueString())
 	i.SetName(plan.Name.ValueString())
-	created, r, err := d.client.InstancesApi.V1CreateInstance(auth).InstanceCreateOptions(*i).Execute()
-	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Error creating instance",
-				"You don't have permission to create instances in this project.",
-			)
+
+	if !plan.OSBuild.IsNull() {
+		i.SetOsbuild(plan.OSBuild.ValueString())
+	}
+
+	if !plan.Patches.IsNull() && !plan.Patches.IsUnknown() {
+		var patches []string
+		diags = plan.Patches.ElementsAs(ctx, &patches, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating instance",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		i.SetPatches(patches)
+	}
+
+	if !plan.IPSW.IsNull() {
+		i.SetFwpackage(plan.IPSW.ValueString())
+	}
+
+	if !plan.Snapshot.IsNull() {
+		i.SetSnapshot(plan.Snapshot.ValueString())
+	}
+
+	if !plan.Encrypt.IsNull() {
+		i.SetEncrypt(plan.Encrypt.ValueBool())
+	}
+
+	if plan.Device != nil {
+		i.SetDevice(*corellium.NewModel(
+			plan.Device.Type.ValueString(),
+			plan.Device.Name.ValueString(),
+			plan.Device.Flavor.ValueString(),
+			plan.Device.Model.ValueString(),
+		))
+	}
+
+	// customBootOptions are the boot options the API client doesn't support yet.
+	customBootOptions := map[string]interface{}{}
+	if plan.BootOptions != nil {
+		b := corellium.NewInstanceBootOptions()
+
+		if !plan.BootOptions.BootArgs.IsNull() && !plan.BootOptions.BootArgs.IsUnknown() {
+			b.SetBootArgs(plan.BootOptions.BootArgs.ValueString())
+		}
+
+		if !plan.BootOptions.RestoreBootArgs.IsNull() && !plan.BootOptions.RestoreBootArgs.IsUnknown() {
+			b.SetRestoreBootArgs(plan.BootOptions.RestoreBootArgs.ValueString())
+		}
+
+		if !plan.BootOptions.UDID.IsNull() && !plan.BootOptions.UDID.IsUnknown() {
+			b.SetUdid(plan.BootOptions.UDID.ValueString())
+		}
+
+		if !plan.BootOptions.ECID.IsNull() && !plan.BootOptions.ECID.IsUnknown() {
+			b.SetEcid(plan.BootOptions.ECID.ValueString())
+		}
+
+		if !plan.BootOptions.RandomSeed.IsNull() && !plan.BootOptions.RandomSeed.IsUnknown() {
+			b.SetRandomSeed(plan.BootOptions.RandomSeed.ValueString())
+		}
+
+		if !plan.BootOptions.PAC.IsNull() && !plan.BootOptions.PAC.IsUnknown() {
+			b.SetPac(plan.BootOptions.PAC.ValueBool())
+		}
+
+		if !plan.BootOptions.APRR.IsNull() && !plan.BootOptions.APRR.IsUnknown() {
+			b.SetAprr(plan.BootOptions.APRR.ValueBool())
+		}
+
+		if !plan.BootOptions.AdditionalTags.IsNull() && !plan.BootOptions.AdditionalTags.IsUnknown() {
+			var tags []corellium.InstanceBootOptionsAdditionalTag
+			diags = plan.BootOptions.AdditionalTags.ElementsAs(ctx, &tags, false)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			b.SetAdditionalTags(tags)
+		}
+
+		i.SetBootOptions(*b)
+
+		if !plan.BootOptions.Screen.IsNull() {
+			customBootOptions["screen"] = plan.BootOptions.Screen.ValueString()
+		}
+	}
+
+	for _, image := range v1InstanceCustomImages {
+		var id types.String
+
+		diags = req.Plan.GetAttribute(ctx, image.path, &id)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error creating instance",
-			"An unexpected error was encountered trying to create the instance:\n\n"+string(b),
-		)
+		if !id.IsNull() {
+			customBootOptions[image.bootOption] = map[string]interface{}{"id": id.ValueString()}
+		}
+	}
+
+	var created *corellium.InstanceReturn
+	var r *http.Response
+	var err error
+	if len(customBootOptions) > 0 {
+		created, r, err = V1CreateInstanceManual(auth, d.client.GetConfig(), *i, customBootOptions)
+	} else {
+		created, r, err = d.client.InstancesApi.V1CreateInstance(auth).InstanceCreateOptions(*i).Execute()
+	}
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error creating instance", "You don't have permission to create instances in this project", apiErr)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error creating instance", "An unexpected error was encountered trying to create the instance", apiErr)
 		return
 	}
 
@@ -523,25 +967,32 @@ func (d *CorelliumV1InstanceResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
-	if !plan.WaitForReady.IsUnknown() && plan.WaitForReady.ValueBool() {
+	// NOTICE: The network monitor can only be started on an instance that is on, so it is waited for.
+	netmon := plan.Netmon != nil && plan.Netmon.Enabled.ValueBool()
+	// NOTICE: A configured state can only be reached after the instance is created, so it is also waited for.
+	target := !plan.State.IsNull() && !plan.State.IsUnknown()
+
+	if (!plan.WaitForReady.IsUnknown() && plan.WaitForReady.ValueBool()) || !plan.WaitFor.IsNull() || netmon || target {
+		createTimeout := V1InstanceDefaultCreateTimeout
+		if !plan.WaitForReadyTimeout.IsNull() {
+			createTimeout = time.Duration(plan.WaitForReadyTimeout.ValueInt64()) * time.Second
+		}
+
+		timeout, diags := plan.Timeouts.Create(ctx, createTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// NOTICE: The instance state and its agent are waited within the same timeout.
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
 		createStateConf := &retry.StateChangeConf{
 			Refresh: func() (interface{}, string, error) {
 				instance, r, err := d.client.InstancesApi.V1GetInstance(auth, created.GetId()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error creating instance",
-							"Coudn't read the response body when checking if instnace is ready: "+err.Error(),
-						)
-						return nil, "", err
-					}
-
-					resp.Diagnostics.AddError(
-						"Error creating instance",
-						"An unexpected error was encountered trying to check if instance is ready:\n\n"+string(b),
-					)
-					return nil, "", err
+					return nil, "", NewAPIError(r, err)
 				}
 
 				return instance, string(instance.GetState()), nil
@@ -549,24 +1000,22 @@ func (d *CorelliumV1InstanceResource) Create(ctx context.Context, req resource.C
 			Pending: []string{
 				V1InstanceStateCreating,
 				V1InstanceStateDeleting,
+				V1InstanceStateBooting,
+				V1InstanceStateRebooting,
+				V1InstanceStateRestoring,
 			},
 			Target: []string{
 				V1InstanceStateOn,
 				V1InstanceStateOff,
 				V1InstanceStatePaused,
 			},
-			Delay:      5 * time.Second,
-			MinTimeout: 5 * time.Second,
-			Timeout: func() time.Duration {
-				if plan.WaitForReadyTimeout.IsUnknown() {
-					return WaitForReadyTimeout
-				}
-
-				return time.Duration(plan.WaitForReadyTimeout.ValueInt64())
-			}() * time.Second,
+			Delay:      pollWait(5 * time.Second),
+			MinTimeout: pollWait(5 * time.Second),
+			Timeout:    timeout,
 		}
 
-		if _, err := createStateConf.WaitForStateContext(ctx); err != nil {
+		instance, err := createStateConf.WaitForStateContext(ctx)
+		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating instance",
 				"Coudn't create the instance: "+err.Error(),
@@ -574,23 +1023,63 @@ func (d *CorelliumV1InstanceResource) Create(ctx context.Context, req resource.C
 
 			return
 		}
+
+		if plan.WaitFor.ValueString() == V1InstanceWaitForAgent && instance.(*corellium.Instance).GetState() == corellium.ON {
+			if err := waitForInstanceAgent(ctx, d.client, auth, created.GetId(), timeout); err != nil {
+				resp.Diagnostics.AddError(
+					"Error creating instance",
+					"Coudn't wait for the instance agent to be ready: "+err.Error(),
+				)
+
+				return
+			}
+		}
+
+		if netmon {
+			r, err := d.setNetmon(auth, created.GetId(), true)
+			if err != nil {
+				addAPIError(&resp.Diagnostics, "Error creating instance", "An unexpected error was encountered trying to start the network monitor", NewAPIError(r, err))
+				return
+			}
+		}
+
+		if current := string(instance.(*corellium.Instance).GetState()); target && current != plan.State.ValueString() {
+			r, err := d.changeInstanceState(auth, created.GetId(), current, plan.State.ValueString())
+			if err != nil {
+				addAPIError(&resp.Diagnostics, "Error creating instance", "An unexpected error was encountered trying to change the instance state to "+plan.State.ValueString(), NewAPIError(r, err))
+				return
+			}
+
+			if err := d.waitForInstanceState(ctx, auth, created.GetId(), plan.State.ValueString(), timeout); err != nil {
+				resp.Diagnostics.AddError(
+					"Error creating instance",
+					"Coudn't change the instance state to "+plan.State.ValueString()+": "+err.Error(),
+				)
+				return
+			}
+		}
 	}
 
-	instance, r, err := d.client.InstancesApi.V1GetInstance(auth, created.GetId()).Execute()
-	if err != nil {
-		b, err := io.ReadAll(r.Body)
+	if !plan.PortForward.IsNull() {
+		proxy, diags := portForwardProxyConfigs(ctx, plan.PortForward)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		p := corellium.NewPatchInstanceOptions()
+		p.SetProxy(proxy)
+
+		_, r, err := d.client.InstancesApi.V1PatchInstance(auth, created.GetId()).PatchInstanceOptions(*p).Execute()
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error get instance",
-				"Coudn't read the response body: "+err.Error(),
-			)
+			addAPIError(&resp.Diagnostics, "Error creating instance", "An unexpected error was encountered trying to forward the instance ports", NewAPIError(r, err))
 			return
 		}
+	}
 
-		resp.Diagnostics.AddError(
-			"Error get instance",
-			"An unexpected error was encountered trying to get the instance:\n\n"+string(b),
-		)
+	instance, r, err := d.client.InstancesApi.V1GetInstance(auth, created.GetId()).Execute()
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error get instance", "An unexpected error was encountered trying to get the instance", NewAPIError(r, err))
 		return
 	}
 
@@ -606,46 +1095,36 @@ func (d *CorelliumV1InstanceResource) Create(ctx context.Context, req resource.C
 	plan.TaskState = types.StringValue(instance.GetTaskState())
 	plan.Error = types.StringValue(instance.GetError())
 
-	additionalTags, diags := types.ListValueFrom(ctx, types.StringType, instance.BootOptions.GetAdditionalTags())
+	bootOptions, diags := bootOptionsFromInstance(ctx, instance, plan.BootOptions)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	plan.BootOptions = &V1InstanceBootOptionsModel{
-		BootArgs:        types.StringValue(instance.BootOptions.GetBootArgs()),
-		RestoreBootArgs: types.StringValue(instance.BootOptions.GetRestoreBootArgs()),
-		UDID:            types.StringValue(instance.BootOptions.GetUdid()),
-		ECID:            types.StringValue(instance.BootOptions.GetEcid()),
-		RandomSeed:      types.StringValue(instance.BootOptions.GetRandomSeed()),
-		PAC:             types.BoolValue(instance.BootOptions.GetPac()),
-		APRR:            types.BoolValue(instance.BootOptions.GetAprr()),
-		AdditionalTags:  additionalTags,
-	}
+	plan.BootOptions = bootOptions
 
-	/*plan.ServiceIP = types.StringValue(instance.GetServiceIp()) // TODO: find the right type for this.
+	plan.ServiceIP = types.StringValue(instance.GetServiceIp())
 	plan.WifiIP = types.StringValue(instance.GetWifiIp())
 	plan.SecondaryIP = types.StringValue(instance.GetSecondaryIp())
 
-
-	proxy, diags := types.MapValueFrom(ctx, types.StringType, instance.Services.GetVpn().Proxy)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	services, err := newV1InstanceServicesModel(instance)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading instance services",
+			"Coudn't read the VPN ports of the instance: "+err.Error(),
+		)
 		return
 	}
 
-	listeners, diags := types.MapValueFrom(ctx, types.StringType, instance.Services.GetVpn().Listeners)
+	plan.Services = services
+
+	endpoints, diags := portForwardEndpoints(ctx, instance, services.VPN.Proxy)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	plan.Services = &V1InstanceServicesModel{
-		VPN: &V1InstanceVPNModel{
-			Proxy:     proxy,
-			Listeners: listeners,
-		},
-	}*/
+	plan.PortForwardEndpoints = endpoints
 
 	plan.Panicked = types.BoolValue(instance.GetPanicked())
 	plan.Created = types.StringValue(instance.GetCreated().UTC().String())
@@ -699,19 +1178,14 @@ func (d *CorelliumV1InstanceResource) Read(ctx context.Context, req resource.Rea
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	instance, r, err := d.client.InstancesApi.V1GetInstance(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error get the instance",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The instance was deleted outside of Terraform, so it's removed from the state to be created again.
+			resp.State.RemoveResource(ctx)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error to get the instance",
-			"An unexpected error was encountered trying to get the instance:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error to get the instance", "An unexpected error was encountered trying to get the instance", apiErr)
 		return
 	}
 
@@ -728,45 +1202,44 @@ func (d *CorelliumV1InstanceResource) Read(ctx context.Context, req resource.Rea
 	state.TaskState = types.StringValue(instance.GetTaskState())
 	state.Error = types.StringValue(instance.GetError())
 
-	additionalTags, diags := types.ListValueFrom(ctx, types.StringType, instance.BootOptions.GetAdditionalTags())
+	bootOptions, diags := bootOptionsFromInstance(ctx, instance, state.BootOptions)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	state.BootOptions = &V1InstanceBootOptionsModel{
-		BootArgs:        types.StringValue(instance.BootOptions.GetBootArgs()),
-		RestoreBootArgs: types.StringValue(instance.BootOptions.GetRestoreBootArgs()),
-		UDID:            types.StringValue(instance.BootOptions.GetUdid()),
-		ECID:            types.StringValue(instance.BootOptions.GetEcid()),
-		RandomSeed:      types.StringValue(instance.BootOptions.GetRandomSeed()),
-		PAC:             types.BoolValue(instance.BootOptions.GetPac()),
-		APRR:            types.BoolValue(instance.BootOptions.GetAprr()),
-		AdditionalTags:  additionalTags,
-	}
+	state.BootOptions = bootOptions
 
 	state.ServiceIP = types.StringValue(instance.GetServiceIp())
 	state.WifiIP = types.StringValue(instance.GetWifiIp())
 	state.SecondaryIP = types.StringValue(instance.GetSecondaryIp())
 
-	/*proxy, diags := types.MapValueFrom(ctx, types.StringType, instance.Services.GetVpn().Proxy) // TODO: find the right type for this.
+	services, err := newV1InstanceServicesModel(instance)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading instance services",
+			"Coudn't read the VPN ports of the instance: "+err.Error(),
+		)
+		return
+	}
+
+	state.Services = services
+
+	portForward, diags := portForwardFromProxy(ctx, state.PortForward, services.VPN.Proxy)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	listeners, diags := types.MapValueFrom(ctx, types.StringType, instance.Services.GetVpn().Listeners)
+	state.PortForward = portForward
+
+	endpoints, diags := portForwardEndpoints(ctx, instance, services.VPN.Proxy)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	state.Services = &V1InstanceServicesModel{
-		VPN: &V1InstanceVPNModel{
-			Proxy:     proxy,
-			Listeners: listeners,
-		},
-	}*/
+	state.PortForwardEndpoints = endpoints
 
 	state.Panicked = types.BoolValue(instance.GetPanicked())
 	state.Created = types.StringValue(instance.GetCreated().UTC().String())
@@ -800,6 +1273,7 @@ func (d *CorelliumV1InstanceResource) Read(ctx context.Context, req resource.Rea
 		Deleted:  types.BoolValue(instance.CreatedBy.GetDeleted()),
 	}
 
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -869,21 +1343,71 @@ func (d *CorelliumV1InstanceResource) Update(ctx context.Context, req resource.U
 	}
 
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
-	instance, r, err := d.client.InstancesApi.V1PatchInstance(auth, state.Id.ValueString()).PatchInstanceOptions(*p).Execute()
-	if err != nil {
-		b, err := io.ReadAll(r.Body)
+
+	// NOTICE: The state is changed before patching the instance, so the patch response already reflects the new state.
+	if !plan.State.IsNull() && !plan.State.IsUnknown() && !plan.State.Equal(state.State) &&
+		state.State.ValueString() != V1InstanceStateCreating {
+		r, err := d.changeInstanceState(auth, state.Id.ValueString(), state.State.ValueString(), plan.State.ValueString())
 		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error updating instance", "An unexpected error was encountered trying to change the instance state to "+plan.State.ValueString(), NewAPIError(r, err))
+			return
+		}
+
+		timeout, diags := plan.Timeouts.Update(ctx, V1InstanceDefaultUpdateTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		if err := d.waitForInstanceState(ctx, auth, state.Id.ValueString(), plan.State.ValueString(), timeout); err != nil {
 			resp.Diagnostics.AddError(
 				"Error updating instance",
-				"Coudn't read the response body: "+err.Error(),
+				"Coudn't change the instance state to "+plan.State.ValueString()+": "+err.Error(),
 			)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error updating instance",
-			"An unexpected error was encountered trying to update the instance:\n\n"+string(b),
-		)
+		if plan.WaitFor.ValueString() == V1InstanceWaitForAgent && plan.State.ValueString() == V1InstanceStateOn {
+			if err := waitForInstanceAgent(ctx, d.client, auth, state.Id.ValueString(), timeout); err != nil {
+				resp.Diagnostics.AddError(
+					"Error updating instance",
+					"Coudn't wait for the instance agent to be ready: "+err.Error(),
+				)
+				return
+			}
+		}
+	}
+
+	if !plan.PortForward.Equal(state.PortForward) {
+		// NOTICE: When the forwarded ports are removed from the configuration, the proxy is sent empty, what stops
+		// forwarding the ports, like an empty set does.
+		proxy := []corellium.ProxyConfig{}
+		if !plan.PortForward.IsNull() {
+			var diags diag.Diagnostics
+			proxy, diags = portForwardProxyConfigs(ctx, plan.PortForward)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		p.SetProxy(proxy)
+	}
+
+	if plan.Netmon != nil && !plan.Netmon.Enabled.IsNull() && !plan.Netmon.Enabled.Equal(state.Netmon.Enabled) {
+		r, err := d.setNetmon(auth, state.Id.ValueString(), plan.Netmon.Enabled.ValueBool())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error updating instance", "An unexpected error was encountered trying to start or stop the network monitor", NewAPIError(r, err))
+			return
+		}
+	}
+
+	instance, r, err := d.client.InstancesApi.V1PatchInstance(auth, state.Id.ValueString()).PatchInstanceOptions(*p).Execute()
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating instance", "An unexpected error was encountered trying to update the instance", NewAPIError(r, err))
 		return
 	}
 
@@ -900,44 +1424,36 @@ func (d *CorelliumV1InstanceResource) Update(ctx context.Context, req resource.U
 	state.TaskState = types.StringValue(instance.GetTaskState())
 	state.Error = types.StringValue(instance.GetError())
 
-	state.BootOptions.BootArgs = types.StringValue(instance.BootOptions.GetBootArgs())
-	state.BootOptions.RestoreBootArgs = types.StringValue(instance.BootOptions.GetRestoreBootArgs())
-	state.BootOptions.UDID = types.StringValue(instance.BootOptions.GetUdid())
-	state.BootOptions.ECID = types.StringValue(instance.BootOptions.GetEcid())
-	state.BootOptions.RandomSeed = types.StringValue(instance.BootOptions.GetRandomSeed())
-	state.BootOptions.PAC = types.BoolValue(instance.BootOptions.GetPac())
-	state.BootOptions.APRR = types.BoolValue(instance.BootOptions.GetAprr())
-
-	additionalTags, diags := types.ListValueFrom(ctx, types.StringType, instance.BootOptions.GetAdditionalTags())
+	bootOptions, diags := bootOptionsFromInstance(ctx, instance, state.BootOptions)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	state.BootOptions.AdditionalTags = additionalTags
+	state.BootOptions = bootOptions
 
 	state.ServiceIP = types.StringValue(instance.GetServiceIp())
 	state.WifiIP = types.StringValue(instance.GetWifiIp())
 	state.SecondaryIP = types.StringValue(instance.GetSecondaryIp())
 
-	/*proxy, diags := types.MapValueFrom(ctx, types.StringType, instance.Services.GetVpn().Proxy) // TODO: find the right type for this.
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	services, err := newV1InstanceServicesModel(instance)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading instance services",
+			"Coudn't read the VPN ports of the instance: "+err.Error(),
+		)
 		return
 	}
 
-	listeners, diags := types.MapValueFrom(ctx, types.StringType, instance.Services.GetVpn().Listeners)
+	state.Services = services
+
+	endpoints, diags := portForwardEndpoints(ctx, instance, services.VPN.Proxy)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	state.Services = &V1InstanceServicesModel{
-		VPN: &V1InstanceVPNModel{
-			Proxy:     proxy,
-			Listeners: listeners,
-		},
-	}*/
+	state.PortForwardEndpoints = endpoints
 
 	state.Panicked = types.BoolValue(instance.GetPanicked())
 	state.Created = types.StringValue(instance.GetCreated().UTC().String())
@@ -970,6 +1486,13 @@ func (d *CorelliumV1InstanceResource) Update(ctx context.Context, req resource.U
 		Deleted:  types.BoolValue(instance.CreatedBy.GetDeleted()),
 	}
 
+	// NOTICE: The API doesn't return the attributes of the provider, so they are taken from the plan.
+	state.WaitForReady = plan.WaitForReady
+	state.WaitForReadyTimeout = plan.WaitForReadyTimeout
+	state.WaitFor = plan.WaitFor
+	state.PortForward = plan.PortForward
+	state.Timeouts = plan.Timeouts
+
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -977,6 +1500,352 @@ func (d *CorelliumV1InstanceResource) Update(ctx context.Context, req resource.U
 	}
 }
 
+// v1InstanceVPNPortAttributes returns the attributes of a port of the instance VPN.
+func v1InstanceVPNPortAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"device_port": schema.Int64Attribute{
+			Description: "Port on the device",
+			Computed:    true,
+		},
+		"router_port": schema.Int64Attribute{
+			Description: "Port on the project router",
+			Computed:    true,
+		},
+		"expose": schema.BoolAttribute{
+			Description: "Port exposed to the external interface",
+			Computed:    true,
+		},
+		"first_available": schema.BoolAttribute{
+			Description: "First available port used if the device port isn't available",
+			Computed:    true,
+		},
+	}
+}
+
+// newV1InstanceServicesModel maps the services returned by the API to the services model.
+func newV1InstanceServicesModel(instance *corellium.Instance) (*V1InstanceServicesModel, error) {
+	vpn := instance.Services.GetVpn()
+
+	proxy, err := newV1InstanceVPNPortModels(vpn.GetProxy())
+	if err != nil {
+		return nil, err
+	}
+
+	listeners, err := newV1InstanceVPNPortModels(vpn.GetListeners())
+	if err != nil {
+		return nil, err
+	}
+
+	return &V1InstanceServicesModel{
+		VPN: &V1InstanceVPNModel{
+			Proxy:     proxy,
+			Listeners: listeners,
+		},
+	}, nil
+}
+
+// newV1InstanceVPNPortModels maps the VPN ports returned by the API to the port models.
+// NOTICE: The API client types the ports as free-form objects, but they have the fields of a proxy configuration, so
+// they are decoded as one.
+func newV1InstanceVPNPortModels(ports []map[string]interface{}) ([]V1InstanceVPNPortModel, error) {
+	b, err := json.Marshal(ports)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []corellium.ProxyConfig
+	if err := json.Unmarshal(b, &configs); err != nil {
+		return nil, err
+	}
+
+	models := make([]V1InstanceVPNPortModel, 0, len(configs))
+	for _, c := range configs {
+		m := V1InstanceVPNPortModel{
+			DevicePort:     types.Int64Null(),
+			RouterPort:     types.Int64Null(),
+			Expose:         types.BoolPointerValue(c.Expose.Get()),
+			FirstAvailable: types.BoolPointerValue(c.FirstAvailable.Get()),
+		}
+		if c.DevicePort.Get() != nil {
+			m.DevicePort = types.Int64Value(int64(c.GetDevicePort()))
+		}
+		if c.RouterPort.Get() != nil {
+			m.RouterPort = types.Int64Value(int64(c.GetRouterPort()))
+		}
+
+		models = append(models, m)
+	}
+
+	return models, nil
+}
+
+// portForwardProxyConfigs maps the forwarded ports to the proxy configurations of the API.
+func portForwardProxyConfigs(ctx context.Context, portForward types.Set) ([]corellium.ProxyConfig, diag.Diagnostics) {
+	var forwards []V1InstancePortForwardModel
+	diags := portForward.ElementsAs(ctx, &forwards, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// NOTICE: The proxy is sent even when it is empty, what stops forwarding every port.
+	proxy := make([]corellium.ProxyConfig, 0, len(forwards))
+	for _, f := range forwards {
+		c := corellium.NewProxyConfig()
+		c.SetDevicePort(float32(f.DevicePort.ValueInt64()))
+		if f.RouterPort.IsNull() {
+			c.SetFirstAvailable(true)
+		} else {
+			c.SetRouterPort(float32(f.RouterPort.ValueInt64()))
+		}
+		c.SetExpose(f.Expose.ValueBool())
+
+		proxy = append(proxy, *c)
+	}
+
+	return proxy, diags
+}
+
+// portForwardFromProxy refreshes the forwarded ports with the ports the instance proxies. A forwarded port the instance
+// doesn't proxy anymore is removed, so it is forwarded again, and the attributes that weren't set are kept null.
+func portForwardFromProxy(ctx context.Context, prior types.Set, proxy []V1InstanceVPNPortModel) (types.Set, diag.Diagnostics) {
+	if prior.IsNull() || prior.IsUnknown() {
+		return prior, nil
+	}
+
+	var forwards []V1InstancePortForwardModel
+	diags := prior.ElementsAs(ctx, &forwards, false)
+	if diags.HasError() {
+		return prior, diags
+	}
+
+	refreshed := make([]V1InstancePortForwardModel, 0, len(forwards))
+	for _, f := range forwards {
+		for _, p := range proxy {
+			if !p.DevicePort.Equal(f.DevicePort) {
+				continue
+			}
+
+			if !f.RouterPort.IsNull() {
+				f.RouterPort = p.RouterPort
+			}
+
+			if !f.Expose.IsNull() || p.Expose.ValueBool() {
+				f.Expose = types.BoolValue(p.Expose.ValueBool())
+			}
+
+			refreshed = append(refreshed, f)
+			break
+		}
+	}
+
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: v1InstancePortForwardAttrTypes}, refreshed)
+}
+
+// portForwardEndpoints returns the endpoints of the ports the instance proxies, the service IP of the instance and the
+// router port, by device port.
+func portForwardEndpoints(ctx context.Context, instance *corellium.Instance, proxy []V1InstanceVPNPortModel) (types.Map, diag.Diagnostics) {
+	endpoints := map[string]string{}
+	for _, p := range proxy {
+		if p.DevicePort.IsNull() || p.RouterPort.IsNull() {
+			continue
+		}
+
+		endpoints[strconv.FormatInt(p.DevicePort.ValueInt64(), 10)] = net.JoinHostPort(instance.GetServiceIp(), strconv.FormatInt(p.RouterPort.ValueInt64(), 10))
+	}
+
+	return types.MapValueFrom(ctx, types.StringType, endpoints)
+}
+
+// bootOptionsFromInstance maps the boot options returned by the API to the boot options model.
+// NOTICE: The API doesn't return the screen size, so it is kept from the prior boot options, if any.
+func bootOptionsFromInstance(ctx context.Context, instance *corellium.Instance, prior *V1InstanceBootOptionsModel) (*V1InstanceBootOptionsModel, diag.Diagnostics) {
+	additionalTags, diags := types.ListValueFrom(ctx, types.StringType, instance.BootOptions.GetAdditionalTags())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	bootOptions := &V1InstanceBootOptionsModel{
+		BootArgs:        types.StringValue(instance.BootOptions.GetBootArgs()),
+		RestoreBootArgs: types.StringValue(instance.BootOptions.GetRestoreBootArgs()),
+		UDID:            types.StringValue(instance.BootOptions.GetUdid()),
+		ECID:            types.StringValue(instance.BootOptions.GetEcid()),
+		RandomSeed:      types.StringValue(instance.BootOptions.GetRandomSeed()),
+		PAC:             types.BoolValue(instance.BootOptions.GetPac()),
+		APRR:            types.BoolValue(instance.BootOptions.GetAprr()),
+		AdditionalTags:  additionalTags,
+		Screen:          types.StringNull(),
+	}
+
+	if prior != nil {
+		bootOptions.Screen = prior.Screen
+	}
+
+	return bootOptions, diags
+}
+
+// v1InstanceCustomImage is an attribute with the id of an image the instance boots with.
+type v1InstanceCustomImage struct {
+	path path.Path
+	// imageType is the type the image must be, e.g. V1ImageTypeKernel.
+	imageType string
+	// bootOption is the boot option the image is sent as when the instance is created.
+	bootOption string
+}
+
+// v1InstanceCustomImages are the attributes of the custom images.
+var v1InstanceCustomImages = []v1InstanceCustomImage{
+	{path: path.Root("custom_kernel"), imageType: V1ImageTypeKernel, bootOption: "kernel"},
+	{path: path.Root("custom_ramdisk"), imageType: V1ImageTypeRamdisk, bootOption: "ramdisk"},
+	{path: path.Root("custom_devicetree"), imageType: V1ImageTypeDevicetree, bootOption: "devicetree"},
+}
+
+// checkCustomImage checks the image with the id exists, is of the type of the custom image, and belongs to the project.
+func (d *CorelliumV1InstanceResource) checkCustomImage(auth context.Context, image v1InstanceCustomImage, id string, project types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	i, r, err := d.client.ImagesApi.V1GetImage(auth, id).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			diags.AddAttributeError(image.path, "Image not found", "Coudn't find the image "+id)
+			return diags
+		}
+
+		addAPIError(&diags, "Error reading image", "An unexpected error was encountered trying to read the image "+id, apiErr)
+		return diags
+	}
+
+	if i.GetType() != image.imageType {
+		diags.AddAttributeError(
+			image.path,
+			"Invalid image type",
+			fmt.Sprintf("The image %s is a %s image, but %s must be a %s image.", i.GetId(), i.GetType(), image.path, image.imageType),
+		)
+	}
+
+	// NOTICE: The project isn't known when the instance is created in the default project, so the API checks it then.
+	if !project.IsNull() && !project.IsUnknown() && i.GetProject() != project.ValueString() {
+		diags.AddAttributeError(
+			image.path,
+			"Invalid image project",
+			fmt.Sprintf("The image %s belongs to the project %s, but the instance to the project %s.", i.GetId(), i.GetProject(), project.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// changeInstanceState sends the start, stop, pause or unpause request required to move the instance
+// from the current state to the target state.
+func (d *CorelliumV1InstanceResource) changeInstanceState(auth context.Context, id, current, target string) (*http.Response, error) {
+	switch target {
+	case V1InstanceStateOn:
+		if current == V1InstanceStatePaused {
+			return d.client.InstancesApi.V1UnpauseInstance(auth, id).Execute()
+		}
+
+		return d.client.InstancesApi.V1StartInstance(auth, id).Execute()
+	case V1InstanceStatePaused:
+		if current == V1InstanceStateOff {
+			// A powered off instance can't be paused, but it can be started already paused.
+			o := corellium.NewInstanceStartOptions()
+			o.SetPaused(true)
+
+			return d.client.InstancesApi.V1StartInstance(auth, id).InstanceStartOptions(*o).Execute()
+		}
+
+		return d.client.InstancesApi.V1PauseInstance(auth, id).Execute()
+	default:
+		// NOTICE: The state attribute validator only allows on, off and paused, so the default is off.
+		return d.client.InstancesApi.V1StopInstance(auth, id).Execute()
+	}
+}
+
+// setNetmon starts the network monitor of the instance when enabled is true, and stops it otherwise.
+func (d *CorelliumV1InstanceResource) setNetmon(auth context.Context, id string, enabled bool) (*http.Response, error) {
+	if enabled {
+		return d.client.InstancesApi.V1StartNetworkMonitor(auth, id).Execute()
+	}
+
+	return d.client.InstancesApi.V1StopNetworkMonitor(auth, id).Execute()
+}
+
+// waitForInstanceState waits until the instance reaches the target state, or the timeout expires.
+func (d *CorelliumV1InstanceResource) waitForInstanceState(ctx context.Context, auth context.Context, id, target string, timeout time.Duration) error {
+	var pending []string
+	for _, s := range []string{
+		V1InstanceStateOn,
+		V1InstanceStateOff,
+		V1InstanceStatePaused,
+		V1InstanceStateBooting,
+		V1InstanceStateRebooting,
+		V1InstanceStateRestoring,
+		V1InstanceStateCreating,
+	} {
+		if s != target {
+			pending = append(pending, s)
+		}
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			instance, r, err := d.client.InstancesApi.V1GetInstance(auth, id).Execute()
+			if err != nil {
+				return nil, "", NewAPIError(r, err)
+			}
+
+			return instance, string(instance.GetState()), nil
+		},
+		Pending:    pending,
+		Target:     []string{target},
+		Delay:      pollWait(5 * time.Second),
+		MinTimeout: pollWait(5 * time.Second),
+		Timeout:    timeout,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// waitForInstanceAgent waits until the agent of the instance is ready, what happens some time after the instance is on,
+// or the timeout expires.
+func waitForInstanceAgent(ctx context.Context, client *corellium.APIClient, auth context.Context, id string, timeout time.Duration) error {
+	const (
+		pending = "pending"
+		ready   = "ready"
+	)
+
+	stateConf := &retry.StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			agent, r, err := client.AgentApi.V1AgentAppReady(auth, id).Execute()
+			if err != nil {
+				apiErr := NewAPIError(r, err)
+				// NOTICE: The agent isn't reachable until the device boots, so only a missing instance or a missing
+				// permission stops the wait.
+				if errors.Is(apiErr, ErrNotFound) || errors.Is(apiErr, ErrForbidden) {
+					return nil, "", apiErr
+				}
+
+				return apiErr, pending, nil
+			}
+
+			if !agent.GetReady() {
+				return agent, pending, nil
+			}
+
+			return agent, ready, nil
+		},
+		Pending:    []string{pending},
+		Target:     []string{ready},
+		Delay:      pollWait(5 * time.Second),
+		MinTimeout: pollWait(5 * time.Second),
+		Timeout:    timeout,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (d *CorelliumV1InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state V1InstanceModel
@@ -987,21 +1856,16 @@ func (d *CorelliumV1InstanceResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, V1InstanceDefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	r, err := d.client.InstancesApi.V1DeleteInstance(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting instance",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Unable to delete instance",
-			"An unexpected error was encountered trying to delete the instance:\n\n"+string(b))
+		addAPIError(&resp.Diagnostics, "Unable to delete instance", "An unexpected error was encountered trying to delete the instance", NewAPIError(r, err))
 		return
 	}
 
@@ -1013,12 +1877,17 @@ func (d *CorelliumV1InstanceResource) Delete(ctx context.Context, req resource.D
 
 	deleteStateConf := &retry.StateChangeConf{
 		Refresh: func() (interface{}, string, error) {
-			instance, _, _ := d.client.InstancesApi.V1GetInstance(auth, state.Id.ValueString()).Execute()
-			if instance != nil {
-				return instance, string(instance.GetState()), nil
+			instance, r, err := d.client.InstancesApi.V1GetInstance(auth, state.Id.ValueString()).Execute()
+			if err != nil {
+				apiErr := NewAPIError(r, err)
+				if errors.Is(apiErr, ErrNotFound) {
+					return deleteStateStructure{Id: state.Id.ValueString()}, deleteState, nil
+				}
+
+				return nil, "", apiErr
 			}
 
-			return deleteStateStructure{Id: state.Id.ValueString()}, deleteState, nil
+			return instance, string(instance.GetState()), nil
 		},
 		Pending: []string{
 			V1InstanceStateDeleting,
@@ -1026,9 +1895,9 @@ func (d *CorelliumV1InstanceResource) Delete(ctx context.Context, req resource.D
 		Target: []string{
 			deleteState,
 		},
-		Delay:      5 * time.Second,
-		MinTimeout: 1 * time.Second,
-		Timeout:    5 * time.Minute,
+		Delay:      pollWait(5 * time.Second),
+		MinTimeout: pollWait(1 * time.Second),
+		Timeout:    timeout,
 	}
 
 	if _, err = deleteStateConf.WaitForStateContext(ctx); err != nil {
@@ -1040,6 +1909,11 @@ func (d *CorelliumV1InstanceResource) Delete(ctx context.Context, req resource.D
 	}
 }
 
+// ImportState imports an existing instance into the Terraform state using its ID.
+func (d *CorelliumV1InstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
 // Configure adds the provider configured client to the resource.
 func (d *CorelliumV1InstanceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -1048,3 +1922,49 @@ func (d *CorelliumV1InstanceResource) Configure(_ context.Context, req resource.
 
 	d.client = req.ProviderData.(*corellium.APIClient)
 }
+
+// *******************************************************************************************************************************
+// Custom method for the InstancesAPI that serves as a workaround for the boot options the API client doesn't support yet.
+
+// V1CreateInstanceManual creates an instance merging the customBootOptions, e.g. kernel, ramdisk, devicetree and screen,
+// into the boot options of the create options. It returns the same values as the API client, with the response body
+// still readable on error.
+func V1CreateInstanceManual(ctx context.Context, cfg *corellium.Configuration, options corellium.InstanceCreateOptions, customBootOptions map[string]interface{}) (*corellium.InstanceReturn, *http.Response, error) {
+	body, err := options.ToMap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bootOptions := map[string]interface{}{}
+	if options.BootOptions != nil {
+		bootOptions, err = options.BootOptions.ToMap()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for k, v := range customBootOptions {
+		bootOptions[k] = v
+	}
+
+	body["bootOptions"] = bootOptions
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, resp, err := doManualRequest(ctx, cfg, http.MethodPost, "/api/v1/instances", bytes.NewReader(payload))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var created corellium.InstanceReturn
+	if err := json.Unmarshal(b, &created); err != nil {
+		return nil, resp, err
+	}
+
+	return &created, resp, nil
+}
+
+// *******************************************************************************************************************************
@@ -0,0 +1,399 @@
+package corellium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &CorelliumV1InstanceActionResource{}
+	_ resource.ResourceWithConfigure      = &CorelliumV1InstanceActionResource{}
+	_ resource.ResourceWithValidateConfig = &CorelliumV1InstanceActionResource{}
+)
+
+// NewCorelliumV1InstanceActionResource is a helper function to simplify the provider implementation.
+func NewCorelliumV1InstanceActionResource() resource.Resource {
+	return &CorelliumV1InstanceActionResource{}
+}
+
+// CorelliumV1InstanceActionResource is the resource implementation.
+type CorelliumV1InstanceActionResource struct {
+	client *corellium.APIClient
+}
+
+const (
+	// V1InstanceActionReboot reboots the instance.
+	V1InstanceActionReboot = "reboot"
+	// V1InstanceActionRestoreSnapshot restores the instance from a snapshot.
+	V1InstanceActionRestoreSnapshot = "restore_snapshot"
+	// V1InstanceActionUpgrade upgrades the instance to a new OS version.
+	V1InstanceActionUpgrade = "upgrade"
+	// V1InstanceActionFactoryReset restores the instance from its fresh snapshot.
+	V1InstanceActionFactoryReset = "factory_reset"
+)
+
+// V1InstanceActionModel maps the resource schema data.
+type V1InstanceActionModel struct {
+	// Id is the action id.
+	Id types.String `tfsdk:"id"`
+	// Instance is the id of the instance to run the action against.
+	Instance types.String `tfsdk:"instance"`
+	// Action is the operation to run.
+	// Action can assume the following values:
+	// reboot - Reboot the instance.
+	// restore_snapshot - Restore the instance from the snapshot.
+	// upgrade - Upgrade the instance to the os and osbuild.
+	// factory_reset - Restore the instance from its fresh snapshot, the one taken when the instance was created.
+	Action types.String `tfsdk:"action"`
+	// Snapshot is the id of the snapshot to restore, required by the restore_snapshot action.
+	Snapshot types.String `tfsdk:"snapshot"`
+	// OS is the version to upgrade to, required by the upgrade action.
+	OS types.String `tfsdk:"os"`
+	// OSBuild is the build to upgrade to, used by the upgrade action.
+	OSBuild types.String `tfsdk:"osbuild"`
+	// Triggers is a map of arbitrary values that, when changed, run the action again.
+	Triggers types.Map `tfsdk:"triggers"`
+	// State is the state of the instance after the action is completed.
+	State types.String `tfsdk:"state"`
+	// TaskState is the task state of the instance after the action is completed.
+	TaskState types.String `tfsdk:"task_state"`
+	// Timeouts is the time to wait for the action to complete.
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// V1InstanceActionDefaultCreateTimeout is the default time to wait for an action to complete.
+const V1InstanceActionDefaultCreateTimeout = 15 * time.Minute
+
+// Metadata returns the resource type name.
+func (d *CorelliumV1InstanceActionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1instance_action"
+	// TypeName is the name of the resource type, which must be unique within the provider.
+	// This is used to identify the resource type in state and plan files.
+	// i.e: resource corellium_v1instance_action "action" { ... }
+}
+
+// Schema defines the schema for the resource.
+func (d *CorelliumV1InstanceActionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Action id",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance id",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"action": schema.StringAttribute{
+				Description: "Action to run against the instance",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						V1InstanceActionReboot,
+						V1InstanceActionRestoreSnapshot,
+						V1InstanceActionUpgrade,
+						V1InstanceActionFactoryReset,
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot": schema.StringAttribute{
+				Description: "Snapshot id to restore",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"os": schema.StringAttribute{
+				Description: "OS version to upgrade to",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"osbuild": schema.StringAttribute{
+				Description: "OS build to upgrade to",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that, when changed, run the action again",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description: "Instance state after the action",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"task_state": schema.StringAttribute{
+				Description: "Instance task state after the action",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks the attributes required by each action.
+func (d *CorelliumV1InstanceActionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config V1InstanceActionModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch config.Action.ValueString() {
+	case V1InstanceActionRestoreSnapshot:
+		if config.Snapshot.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("snapshot"),
+				"Missing snapshot",
+				"The snapshot attribute is required by the "+V1InstanceActionRestoreSnapshot+" action.",
+			)
+		}
+	case V1InstanceActionUpgrade:
+		if config.OS.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("os"),
+				"Missing os",
+				"The os attribute is required by the "+V1InstanceActionUpgrade+" action.",
+			)
+		}
+	}
+}
+
+// Create runs the action against the instance and sets the initial Terraform state.
+func (d *CorelliumV1InstanceActionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan V1InstanceActionModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, V1InstanceActionDefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	instanceId := plan.Instance.ValueString()
+
+	var r *http.Response
+	var err error
+	switch plan.Action.ValueString() {
+	case V1InstanceActionReboot:
+		r, err = d.client.InstancesApi.V1RebootInstance(auth, instanceId).Execute()
+	case V1InstanceActionRestoreSnapshot:
+		r, err = d.client.SnapshotsApi.V1RestoreInstanceSnapshot(auth, instanceId, plan.Snapshot.ValueString()).Execute()
+	case V1InstanceActionUpgrade:
+		u := corellium.NewInstanceUpgradeBody(plan.OS.ValueString())
+		if !plan.OSBuild.IsNull() {
+			u.SetOsbuild(plan.OSBuild.ValueString())
+		}
+
+		r, err = d.client.InstancesApi.V1UpgradeInstance(auth, instanceId).InstanceUpgradeBody(*u).Execute()
+	case V1InstanceActionFactoryReset:
+		// NOTICE: The API doesn't have a factory reset endpoint, so the instance is restored from its fresh snapshot,
+		// the one Corellium takes when the instance is created.
+		snapshots, sr, serr := d.client.SnapshotsApi.V1GetInstanceSnapshots(auth, instanceId).Execute()
+		if serr != nil {
+			r, err = sr, serr
+			break
+		}
+
+		var fresh string
+		for _, snapshot := range snapshots {
+			if snapshot.GetFresh() {
+				fresh = snapshot.GetId()
+				break
+			}
+		}
+
+		if fresh == "" {
+			resp.Diagnostics.AddError(
+				"Error running instance action",
+				"The instance "+instanceId+" doesn't have a fresh snapshot to factory reset from.",
+			)
+			return
+		}
+
+		r, err = d.client.SnapshotsApi.V1RestoreInstanceSnapshot(auth, instanceId, fresh).Execute()
+	}
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error running instance action", "You don't have permission to run the "+plan.Action.ValueString()+" action on this instance", apiErr)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error running instance action", "An unexpected error was encountered trying to run the "+plan.Action.ValueString()+" action", apiErr)
+		return
+	}
+
+	instance, err := waitForInstanceTask(ctx, d.client, auth, instanceId, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running instance action",
+			"Coudn't wait for the "+plan.Action.ValueString()+" action to complete: "+err.Error(),
+		)
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error generating UUID",
+			"An unexpected error was encountered trying to generate the ID:\n\n"+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(id)
+	plan.State = types.StringValue(string(instance.GetState()))
+	plan.TaskState = types.StringValue(instance.GetTaskState())
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// waitForInstanceTask waits until the instance finishes its task, e.g. an action or a snapshot restore, i.e. it is on,
+// off or paused without any task running, or the timeout expires.
+func waitForInstanceTask(ctx context.Context, client *corellium.APIClient, auth context.Context, id string, timeout time.Duration) (*corellium.Instance, error) {
+	const (
+		pending = "pending"
+		done    = "done"
+	)
+
+	stateConf := &retry.StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			instance, r, err := client.InstancesApi.V1GetInstance(auth, id).Execute()
+			if err != nil {
+				return nil, "", NewAPIError(r, err)
+			}
+
+			switch string(instance.GetState()) {
+			case V1InstanceStateOn, V1InstanceStateOff, V1InstanceStatePaused:
+				if t := instance.GetTaskState(); t != "" && t != V1InstancesTaskStateNone {
+					return instance, pending, nil
+				}
+
+				return instance, done, nil
+			case V1InstanceStateError:
+				return nil, "", fmt.Errorf("the instance is in an error state: %s", instance.GetError())
+			default:
+				return instance, pending, nil
+			}
+		},
+		Pending:    []string{pending},
+		Target:     []string{done},
+		Delay:      pollWait(5 * time.Second),
+		MinTimeout: pollWait(5 * time.Second),
+		Timeout:    timeout,
+	}
+
+	instance, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.(*corellium.Instance), nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+// NOTICE: An action is a one-shot operation, so there is nothing to refresh from the API.
+func (d *CorelliumV1InstanceActionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state V1InstanceActionModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// NOTICE: Every attribute but timeouts requires a replacement, so the action runs again in Create instead.
+func (d *CorelliumV1InstanceActionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan V1InstanceActionModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the action from the Terraform state. The instance itself isn't changed.
+func (d *CorelliumV1InstanceActionResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (d *CorelliumV1InstanceActionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
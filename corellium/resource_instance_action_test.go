@@ -0,0 +1,269 @@
+package corellium
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCorelliumV1InstanceActionResource_reboot(t *testing.T) {
+	projectConfig := `
+    resource "corellium_v1project" "test" {
+        name = "` + t.Name() + `"
+        settings = {
+            version = 1
+            internet_access = false
+            dhcp = false
+        }
+        quotas = {
+            cores = 2
+        }
+        users = []
+        teams = []
+        keys  = []
+    }
+    `
+
+	instanceConfig := `
+    resource "corellium_v1instance" "test" {
+        name = "test"
+        flavor = "iphone7plus"
+        project = corellium_v1project.test.id
+        os = "15.7.5"
+        wait_for_ready = true
+        timeouts {
+            create = "10m"
+        }
+    }
+    `
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + projectConfig + instanceConfig + `
+                resource "corellium_v1instance_action" "test" {
+                    instance = corellium_v1instance.test.id
+                    action = "reboot"
+                    triggers = {
+                        run = "1"
+                    }
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("corellium_v1instance_action.test", "id"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "action", "reboot"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "state", "on"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig + `
+                resource "corellium_v1instance_action" "test" {
+                    instance = corellium_v1instance.test.id
+                    action = "reboot"
+                    triggers = {
+                        run = "2"
+                    }
+                    timeouts {
+                        create = "20m"
+                    }
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "triggers.run", "2"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "state", "on"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig + `
+                resource "corellium_v1instance_action" "test" {
+                    instance = corellium_v1instance.test.id
+                    action = "reboot"
+                    triggers = {
+                        run = "2"
+                    }
+                    timeouts {
+                        create = "30m"
+                    }
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "timeouts.create", "30m"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "state", "on"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceActionResource_restore_snapshot(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+                resource "corellium_v1project" "test" {
+                    name = "` + t.Name() + `"
+                    settings = {
+                        version = 1
+                        internet_access = false
+                        dhcp = false
+                    }
+                    quotas = {
+                        cores = 2
+                    }
+                    users = []
+                    teams = []
+                    keys  = []
+                }
+
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    wait_for_ready = true
+                    timeouts {
+                        create = "10m"
+                    }
+                }
+
+                resource "corellium_v1snapshot" "test" {
+                    name = "test"
+                    instance = corellium_v1instance.test.id
+                }
+
+                resource "corellium_v1instance_action" "test" {
+                    instance = corellium_v1instance.test.id
+                    action = "restore_snapshot"
+                    snapshot = corellium_v1snapshot.test.id
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("corellium_v1instance_action.test", "id"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "action", "restore_snapshot"),
+					resource.TestCheckResourceAttrPair("corellium_v1instance_action.test", "snapshot", "corellium_v1snapshot.test", "id"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "state", "on"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceActionResource_upgrade(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+                resource "corellium_v1project" "test" {
+                    name = "` + t.Name() + `"
+                    settings = {
+                        version = 1
+                        internet_access = false
+                        dhcp = false
+                    }
+                    quotas = {
+                        cores = 2
+                    }
+                    users = []
+                    teams = []
+                    keys  = []
+                }
+
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    wait_for_ready = true
+                    timeouts {
+                        create = "10m"
+                    }
+                }
+
+                resource "corellium_v1instance_action" "test" {
+                    instance = corellium_v1instance.test.id
+                    action = "upgrade"
+                    os = "15.7.5"
+                    osbuild = "19H332"
+                    timeouts {
+                        create = "30m"
+                    }
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("corellium_v1instance_action.test", "id"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "action", "upgrade"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "os", "15.7.5"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "state", "on"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceActionResource_factory_reset(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+                resource "corellium_v1project" "test" {
+                    name = "` + t.Name() + `"
+                    settings = {
+                        version = 1
+                        internet_access = false
+                        dhcp = false
+                    }
+                    quotas = {
+                        cores = 2
+                    }
+                    users = []
+                    teams = []
+                    keys  = []
+                }
+
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    wait_for_ready = true
+                    timeouts {
+                        create = "10m"
+                    }
+                }
+
+                resource "corellium_v1instance_action" "test" {
+                    instance = corellium_v1instance.test.id
+                    action = "factory_reset"
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("corellium_v1instance_action.test", "id"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "action", "factory_reset"),
+					resource.TestCheckResourceAttr("corellium_v1instance_action.test", "state", "on"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceActionResource_missing_snapshot(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+                resource "corellium_v1instance_action" "test" {
+                    instance = "00000000-0000-4000-0000-000000000000"
+                    action = "restore_snapshot"
+                }
+                `,
+				ExpectError: regexp.MustCompile("The snapshot attribute is required"),
+			},
+		},
+	})
+}
@@ -0,0 +1,511 @@
+package corellium
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CorelliumV1InstanceAppResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1InstanceAppResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1InstanceAppResource{}
+	_ resource.ResourceWithModifyPlan  = &CorelliumV1InstanceAppResource{}
+)
+
+// NewCorelliumV1InstanceAppResource is a helper function to simplify the provider implementation.
+func NewCorelliumV1InstanceAppResource() resource.Resource {
+	return &CorelliumV1InstanceAppResource{}
+}
+
+// CorelliumV1InstanceAppResource is the resource implementation. It installs an app, an IPA or APK file, on an
+// instance through the instance agent.
+type CorelliumV1InstanceAppResource struct {
+	client *corellium.APIClient
+}
+
+// V1InstanceAppModel maps the resource schema data.
+type V1InstanceAppModel struct {
+	// Id is the instance ID and the app bundle ID, separated by a slash.
+	Id types.String `tfsdk:"id"`
+	// Instance is the ID of the instance to install the app on.
+	Instance types.String `tfsdk:"instance"`
+	// Path is the local path of the IPA or APK file to install.
+	// NOTICE: The API has no endpoint to download an image, so an app can't be installed from a corellium_v1image.
+	Path types.String `tfsdk:"path"`
+	// SHA256 is the hash of the file, what tells a new build of the app apart, as the agent doesn't return the app
+	// version.
+	SHA256 types.String `tfsdk:"sha256"`
+	// BundleId is the bundle ID of the app. When it isn't set, it is the app that shows up after the installation.
+	BundleId types.String `tfsdk:"bundle_id"`
+	// Name is the app name.
+	Name types.String `tfsdk:"name"`
+	// ApplicationType is the app type, e.g. User or System.
+	ApplicationType types.String `tfsdk:"application_type"`
+	// Running is a boolean that indicates if the app is running. When it is set, the app is launched or killed.
+	// NOTICE: The app permissions aren't managed, as the agent API has no endpoint for them.
+	Running types.Bool `tfsdk:"running"`
+	// Timeouts is the time to wait for the app to be installed.
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+const (
+	// V1InstanceAppDefaultCreateTimeout is the default time to wait for an app to be uploaded and installed.
+	V1InstanceAppDefaultCreateTimeout = 10 * time.Minute
+)
+
+// Metadata returns the resource type name.
+func (d *CorelliumV1InstanceAppResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1instance_app"
+}
+
+// Schema defines the schema for the resource.
+func (d *CorelliumV1InstanceAppResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "App ID, the instance ID and the bundle ID separated by a slash",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance ID",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// NOTICE: The replacement of the app, when the path changes, is planned in ModifyPlan, as an imported app has
+			// no path yet.
+			"path": schema.StringAttribute{
+				Description: "Local path of the IPA or APK file",
+				Required:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 hash of the file",
+				Computed:    true,
+			},
+			"bundle_id": schema.StringAttribute{
+				Description: "App bundle ID",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "App name",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_type": schema.StringAttribute{
+				Description: "App type",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"running": schema.BoolAttribute{
+				Description: "App running, what launches or kills the app when it is set",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan hashes the file, and replaces the app when the file changed since it was installed.
+func (d *CorelliumV1InstanceAppResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan V1InstanceAppModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Path.IsUnknown() {
+		return
+	}
+
+	sum, err := fileSHA256(plan.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"Unable to read the app file",
+			"Coudn't hash the file "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sha256"), types.StringValue(sum))...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state V1InstanceAppModel
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// NOTICE: An imported app has neither path nor hash, as the agent doesn't return them, so the file in the
+	// configuration is taken as the installed one, instead of installing it again.
+	if state.SHA256.IsNull() {
+		return
+	}
+
+	if !state.Path.Equal(plan.Path) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("path"))
+	}
+
+	if state.SHA256.ValueString() != sum {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("sha256"))
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (d *CorelliumV1InstanceAppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan V1InstanceAppModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, V1InstanceAppDefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	instanceId := plan.Instance.ValueString()
+
+	before, r, err := d.client.AgentApi.V1AgentListApps(auth, instanceId).Execute()
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error installing app", "An unexpected error was encountered trying to list the apps of the instance", NewAPIError(r, err))
+		return
+	}
+
+	// NOTICE: The agent installs apps from a file on the device, so the file is uploaded to a temporary path first.
+	temp, r, err := d.client.AgentApi.V1AgentGetTempFilename(auth, instanceId).Execute()
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error installing app", "An unexpected error was encountered trying to get a temporary file name on the instance", NewAPIError(r, err))
+		return
+	}
+
+	file, err := os.Open(plan.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error installing app",
+			"Coudn't open the file "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	defer file.Close()
+
+	r, err = d.client.AgentApi.V1AgentUploadFile(auth, instanceId, temp).Body(file).Execute()
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error installing app", "An unexpected error was encountered trying to upload the app to the instance", NewAPIError(r, err))
+		return
+	}
+
+	body := corellium.NewAgentInstallBody()
+	body.SetPath(temp)
+
+	r, err = d.client.AgentApi.V1AgentInstallApp(auth, instanceId).AgentInstallBody(*body).Execute()
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error installing app", "An unexpected error was encountered trying to install the app", NewAPIError(r, err))
+		return
+	}
+
+	if r, err := d.client.AgentApi.V1AgentDeleteFile(auth, instanceId, temp).Execute(); err != nil {
+		// NOTICE: The app is already installed, so a temporary file left behind isn't an error.
+		resp.Diagnostics.AddWarning(
+			"Unable to delete the temporary file",
+			NewAPIError(r, err).Detail("The app was installed, but the temporary file "+temp+" couldn't be deleted"),
+		)
+	}
+
+	after, r, err := d.client.AgentApi.V1AgentListApps(auth, instanceId).Execute()
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error installing app", "An unexpected error was encountered trying to list the apps of the instance", NewAPIError(r, err))
+		return
+	}
+
+	bundleId := plan.BundleId.ValueString()
+	if plan.BundleId.IsUnknown() || plan.BundleId.IsNull() {
+		installed := newApps(before.GetApps(), after.GetApps())
+		if len(installed) != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bundle_id"),
+				"Error installing app",
+				"The bundle ID of the installed app couldn't be found, what happens when the app was already installed. Set the bundle_id of the app.",
+			)
+			return
+		}
+
+		bundleId = installed[0].GetBundleID()
+	}
+
+	app := findApp(after.GetApps(), bundleId)
+	if app == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("bundle_id"),
+			"Error installing app",
+			"The app "+bundleId+" wasn't found on the instance after it was installed",
+		)
+		return
+	}
+
+	if !plan.Running.IsUnknown() && !plan.Running.IsNull() && plan.Running.ValueBool() != app.GetRunning() {
+		if err := d.setRunning(auth, instanceId, bundleId, plan.Running.ValueBool()); err != nil {
+			addAPIError(&resp.Diagnostics, "Error installing app", "An unexpected error was encountered trying to launch or kill the app", err)
+			return
+		}
+
+		app.SetRunning(plan.Running.ValueBool())
+	}
+
+	plan.Id = types.StringValue(instanceId + "/" + bundleId)
+	plan.BundleId = types.StringValue(bundleId)
+	plan.Name = types.StringValue(app.GetName())
+	plan.ApplicationType = types.StringValue(app.GetApplicationType())
+	plan.Running = types.BoolValue(app.GetRunning())
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *CorelliumV1InstanceAppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state V1InstanceAppModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	apps, r, err := d.client.AgentApi.V1AgentListApps(auth, state.Instance.ValueString()).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The instance was deleted outside of Terraform, so the app is removed from the state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Unable to read app", "An unexpected error was encountered trying to list the apps of the instance", apiErr)
+		return
+	}
+
+	app := findApp(apps.GetApps(), state.BundleId.ValueString())
+	if app == nil {
+		// NOTICE: The app was uninstalled outside of Terraform, so it's removed from the state to be installed again.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Name = types.StringValue(app.GetName())
+	state.ApplicationType = types.StringValue(app.GetApplicationType())
+	state.Running = types.BoolValue(app.GetRunning())
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update launches or kills the app, what is the only change that doesn't replace the app.
+func (d *CorelliumV1InstanceAppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state V1InstanceAppModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Running.IsUnknown() && !plan.Running.IsNull() && !plan.Running.Equal(state.Running) {
+		auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+		if err := d.setRunning(auth, state.Instance.ValueString(), state.BundleId.ValueString(), plan.Running.ValueBool()); err != nil {
+			addAPIError(&resp.Diagnostics, "Error updating app", "An unexpected error was encountered trying to launch or kill the app", err)
+			return
+		}
+
+		state.Running = plan.Running
+	}
+
+	// NOTICE: The path and the hash only change here when the app was imported, otherwise the app is replaced.
+	state.Path = plan.Path
+	state.SHA256 = plan.SHA256
+	state.Timeouts = plan.Timeouts
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (d *CorelliumV1InstanceAppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state V1InstanceAppModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	_, r, err := d.client.AgentApi.V1AgentUninstallApp(auth, state.Instance.ValueString(), state.BundleId.ValueString()).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The app, or the instance, was already deleted, so there is nothing to uninstall.
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting app", "An unexpected error was encountered trying to uninstall the app", apiErr)
+		return
+	}
+}
+
+// ImportState imports an installed app into the Terraform state using the instance ID and the bundle ID, separated by
+// a slash.
+func (d *CorelliumV1InstanceAppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	instanceId, bundleId, ok := strings.Cut(req.ID, "/")
+	if !ok || instanceId == "" || bundleId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"The import ID must be the instance ID and the bundle ID separated by a slash, e.g. <instance>/<bundle_id>",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), instanceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bundle_id"), bundleId)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (d *CorelliumV1InstanceAppResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
+
+// setRunning launches the app when running is true, and kills it otherwise.
+func (d *CorelliumV1InstanceAppResource) setRunning(auth context.Context, instanceId, bundleId string, running bool) *APIError {
+	if running {
+		_, r, err := d.client.AgentApi.V1AgentRunApp(auth, instanceId, bundleId).Execute()
+		if err != nil {
+			return NewAPIError(r, err)
+		}
+
+		return nil
+	}
+
+	_, r, err := d.client.AgentApi.V1AgentKillApp(auth, instanceId, bundleId).Execute()
+	if err != nil {
+		return NewAPIError(r, err)
+	}
+
+	return nil
+}
+
+// findApp returns the app with the bundle ID, or nil when it isn't in the apps.
+func findApp(apps []corellium.AgentApp, bundleId string) *corellium.AgentApp {
+	for _, app := range apps {
+		if app.GetBundleID() == bundleId {
+			return &app
+		}
+	}
+
+	return nil
+}
+
+// newApps returns the apps of after that aren't in before.
+func newApps(before, after []corellium.AgentApp) []corellium.AgentApp {
+	var apps []corellium.AgentApp
+	for _, app := range after {
+		if findApp(before, app.GetBundleID()) == nil {
+			apps = append(apps, app)
+		}
+	}
+
+	return apps
+}
+
+// fileSHA256 returns the hex encoded SHA256 hash of the file contents.
+func fileSHA256(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,198 @@
+package corellium
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"terraform-provider-corellium/corellium/pkg/mock"
+)
+
+func TestAccCorelliumV1InstanceAppResource(t *testing.T) {
+	if testAccMock == nil {
+		t.Skip("The tests don't have an app package to install on a real instance.")
+	}
+
+	app := filepath.Join(t.TempDir(), "app.ipa")
+	v1, v2 := []byte("mock app v1"), []byte("mock app v2")
+
+	config := func(running bool) string {
+		return providerConfig + fmt.Sprintf(`
+        resource "corellium_v1project" "test" {
+            name = "`+t.Name()+`"
+            settings = {
+                version = 1
+                internet_access = true
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+
+        resource "corellium_v1instance" "test" {
+            name = "test-app"
+            flavor = "iphone7plus"
+            project = corellium_v1project.test.id
+            os = "15.7.5"
+            wait_for = "agent"
+        }
+
+        resource "corellium_v1instance_app" "test" {
+            instance = corellium_v1instance.test.id
+            path = %q
+            running = %t
+        }
+        `, app, running)
+	}
+
+	writeApp := func(contents []byte) func() {
+		return func() {
+			if err := os.WriteFile(app, contents, 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// NOTICE: The file must exist before the first plan, as it is hashed at plan time.
+	writeApp(v1)()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "bundle_id", mock.AppBundleID(v1)),
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "sha256", fmt.Sprintf("%x", sha256.Sum256(v1))),
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "name", "Mock App"),
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "application_type", "User"),
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "running", "true"),
+				),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "bundle_id", mock.AppBundleID(v1)),
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "running", "false"),
+				),
+			},
+			{
+				// NOTICE: The path and the hash aren't known until the configuration is planned, what
+				// TestAccCorelliumV1InstanceAppResource_import checks.
+				ResourceName:            "corellium_v1instance_app.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"path", "sha256"},
+			},
+			{
+				PreConfig: writeApp(v2),
+				Config:    config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "bundle_id", mock.AppBundleID(v2)),
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "sha256", fmt.Sprintf("%x", sha256.Sum256(v2))),
+					resource.TestCheckResourceAttr("corellium_v1instance_app.test", "running", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceAppResource_import(t *testing.T) {
+	if testAccMock == nil {
+		t.Skip("The tests don't have an app package to install on a real instance.")
+	}
+
+	app := filepath.Join(t.TempDir(), "app.ipa")
+	if err := os.WriteFile(app, []byte("mock app"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(imported bool) string {
+		config := providerConfig + fmt.Sprintf(`
+        resource "corellium_v1project" "test" {
+            name = "`+t.Name()+`"
+            settings = {
+                version = 1
+                internet_access = true
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+
+        resource "corellium_v1instance" "test" {
+            name = "test-app"
+            flavor = "iphone7plus"
+            project = corellium_v1project.test.id
+            os = "15.7.5"
+            wait_for = "agent"
+        }
+
+        resource "corellium_v1instance_app" "test" {
+            instance = corellium_v1instance.test.id
+            path = %q
+        }
+        `, app)
+
+		// NOTICE: The installed app is imported again under another address, so the plan of an imported app is
+		// checked without removing the app from the state.
+		if imported {
+			config += fmt.Sprintf(`
+            resource "corellium_v1instance_app" "imported" {
+                instance = corellium_v1instance.test.id
+                path = %q
+            }
+            `, app)
+		}
+
+		return config
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+			},
+			{
+				Config:       config(true),
+				ResourceName: "corellium_v1instance_app.imported",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["corellium_v1instance_app.test"].Primary.ID, nil
+				},
+				ImportStatePersist: true,
+			},
+			{
+				// The imported app gets the path and the hash of the file, and it isn't installed again.
+				Config: config(true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("corellium_v1instance_app.imported", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("corellium_v1instance_app.imported", "sha256", "corellium_v1instance_app.test", "sha256"),
+					resource.TestCheckResourceAttrPair("corellium_v1instance_app.imported", "path", "corellium_v1instance_app.test", "path"),
+				),
+			},
+			{
+				Config:   config(true),
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,381 @@
+package corellium
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CorelliumV1InstanceFileResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1InstanceFileResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1InstanceFileResource{}
+	_ resource.ResourceWithModifyPlan  = &CorelliumV1InstanceFileResource{}
+)
+
+// NewCorelliumV1InstanceFileResource is a helper function to simplify the provider implementation.
+func NewCorelliumV1InstanceFileResource() resource.Resource {
+	return &CorelliumV1InstanceFileResource{}
+}
+
+// CorelliumV1InstanceFileResource is the resource implementation. It uploads a file to the instance filesystem
+// through the instance agent.
+type CorelliumV1InstanceFileResource struct {
+	client *corellium.APIClient
+}
+
+// V1InstanceFileModel maps the resource schema data.
+type V1InstanceFileModel struct {
+	// Id is the instance ID followed by the file path, e.g. <instance>/data/local/tmp/file.
+	Id types.String `tfsdk:"id"`
+	// Instance is the ID of the instance to upload the file to.
+	Instance types.String `tfsdk:"instance"`
+	// Path is the absolute path of the file on the instance.
+	Path types.String `tfsdk:"path"`
+	// Source is the local path of the file to upload.
+	Source types.String `tfsdk:"source"`
+	// Content is the content of the file to upload, when it isn't uploaded from a local file.
+	Content types.String `tfsdk:"content"`
+	// SHA256 is the hash of the file contents. It's computed at plan time from the source, or the content, and read
+	// from the instance at refresh, so a change on any side uploads the file again.
+	SHA256 types.String `tfsdk:"sha256"`
+}
+
+// Metadata returns the resource type name.
+func (d *CorelliumV1InstanceFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1instance_file"
+}
+
+// Schema defines the schema for the resource.
+func (d *CorelliumV1InstanceFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "File ID, the instance ID followed by the file path",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance ID",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "Absolute path of the file on the instance",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/[^/]`), "must be an absolute path"),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description: "Local path of the file to upload",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content")),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the file to upload",
+				Optional:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 hash of the file contents",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ModifyPlan hashes the source, or the content, so the file is uploaded again when it changes.
+func (d *CorelliumV1InstanceFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan V1InstanceFileModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Source.IsUnknown() || plan.Content.IsUnknown() {
+		return
+	}
+
+	if !plan.Content.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sha256"), types.StringValue(contentSHA256([]byte(plan.Content.ValueString()))))...)
+		return
+	}
+
+	if plan.Source.IsNull() {
+		return
+	}
+
+	sum, err := fileSHA256(plan.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"Unable to read the source file",
+			"Coudn't hash the file "+plan.Source.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sha256"), types.StringValue(sum))...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (d *CorelliumV1InstanceFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan V1InstanceFileModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sum, err := d.upload(ctx, plan)
+	if err != nil {
+		addFileError(&resp.Diagnostics, "Error creating file", err)
+		return
+	}
+
+	plan.Id = types.StringValue(plan.Instance.ValueString() + plan.Path.ValueString())
+	plan.SHA256 = types.StringValue(sum)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *CorelliumV1InstanceFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state V1InstanceFileModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	f, r, err := d.client.AgentApi.V1AgentGetFile(auth, state.Instance.ValueString(), state.Path.ValueString()).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The file, or the instance, was deleted outside of Terraform, so it's removed from the state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Unable to read file", "An unexpected error was encountered trying to download the file", apiErr)
+		return
+	}
+
+	// NOTICE: An empty file is returned as nil, and any other file is a temporary file the client doesn't remove.
+	sum := contentSHA256(nil)
+	if f != nil {
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read file",
+				"Coudn't hash the downloaded file: "+err.Error(),
+			)
+			return
+		}
+
+		sum = hex.EncodeToString(h.Sum(nil))
+	}
+
+	state.SHA256 = types.StringValue(sum)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update uploads the file again, what is the only change that doesn't replace the file.
+func (d *CorelliumV1InstanceFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state V1InstanceFileModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Source = plan.Source
+	state.Content = plan.Content
+
+	if !plan.SHA256.Equal(state.SHA256) {
+		sum, err := d.upload(ctx, state)
+		if err != nil {
+			addFileError(&resp.Diagnostics, "Error updating file", err)
+			return
+		}
+
+		state.SHA256 = types.StringValue(sum)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (d *CorelliumV1InstanceFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state V1InstanceFileModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	r, err := d.client.AgentApi.V1AgentDeleteFile(auth, state.Instance.ValueString(), state.Path.ValueString()).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The file, or the instance, was already deleted, so there is nothing to delete.
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting file", "An unexpected error was encountered trying to delete the file", apiErr)
+		return
+	}
+}
+
+// ImportState imports a file into the Terraform state using the instance ID followed by the file path, e.g.
+// <instance>/data/local/tmp/file.
+func (d *CorelliumV1InstanceFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	instanceId, filePath, ok := strings.Cut(req.ID, "/")
+	if !ok || instanceId == "" || filePath == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"The import ID must be the instance ID followed by the file path, e.g. <instance>/data/local/tmp/file",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), instanceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), "/"+filePath)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (d *CorelliumV1InstanceFileResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
+
+// upload uploads the source, or the content, of the file to the instance, and returns the hash of what was uploaded.
+func (d *CorelliumV1InstanceFileResource) upload(ctx context.Context, file V1InstanceFileModel) (string, error) {
+	var f *os.File
+	if !file.Content.IsNull() {
+		// NOTICE: The client only uploads files, so the content is written to a temporary file first.
+		tmp, err := os.CreateTemp("", "corellium-file")
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		if _, err := tmp.WriteString(file.Content.ValueString()); err != nil {
+			return "", err
+		}
+
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+
+		f = tmp
+	} else {
+		src, err := os.Open(file.Source.ValueString())
+		if err != nil {
+			return "", err
+		}
+		defer src.Close()
+
+		f = src
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	r, err := d.client.AgentApi.V1AgentUploadFile(auth, file.Instance.ValueString(), file.Path.ValueString()).Body(f).Execute()
+	if err != nil {
+		return "", NewAPIError(r, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// addFileError appends the error of an upload to the diagnostics, what is either an API error or a local file error.
+func addFileError(diags *diag.Diagnostics, summary string, err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		addAPIError(diags, summary, "An unexpected error was encountered trying to upload the file", apiErr)
+		return
+	}
+
+	diags.AddError(summary, "Coudn't read the file to upload: "+err.Error())
+}
+
+// contentSHA256 returns the hex encoded SHA256 hash of the contents.
+func contentSHA256(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
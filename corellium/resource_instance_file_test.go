@@ -0,0 +1,92 @@
+package corellium
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCorelliumV1InstanceFileResource(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(source, []byte("mock certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := providerConfig + `
+    resource "corellium_v1project" "test" {
+        name = "` + t.Name() + `"
+        settings = {
+            version = 1
+            internet_access = true
+            dhcp = false
+        }
+        quotas = {
+            cores = 2
+        }
+        users = []
+        teams = []
+        keys  = []
+    }
+
+    resource "corellium_v1instance" "test" {
+        name = "test-file"
+        flavor = "ranchu"
+        project = corellium_v1project.test.id
+        os = "13.0.0"
+        wait_for = "agent"
+    }
+    `
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+                resource "corellium_v1instance_file" "test" {
+                    instance = corellium_v1instance.test.id
+                    path = "/data/local/tmp/fixture.txt"
+                    content = "fixture v1"
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_file.test", "sha256", fmt.Sprintf("%x", sha256.Sum256([]byte("fixture v1")))),
+					resource.TestCheckResourceAttrPair("corellium_v1instance_file.test", "instance", "corellium_v1instance.test", "id"),
+				),
+			},
+			{
+				Config: config + `
+                resource "corellium_v1instance_file" "test" {
+                    instance = corellium_v1instance.test.id
+                    path = "/data/local/tmp/fixture.txt"
+                    content = "fixture v2"
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_file.test", "sha256", fmt.Sprintf("%x", sha256.Sum256([]byte("fixture v2")))),
+				),
+			},
+			{
+				ResourceName:            "corellium_v1instance_file.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content"},
+			},
+			{
+				Config: config + fmt.Sprintf(`
+                resource "corellium_v1instance_file" "test" {
+                    instance = corellium_v1instance.test.id
+                    path = "/data/local/tmp/fixture.txt"
+                    source = %q
+                }
+                `, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_file.test", "sha256", fmt.Sprintf("%x", sha256.Sum256([]byte("mock certificate")))),
+				),
+			},
+		},
+	})
+}
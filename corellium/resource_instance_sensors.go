@@ -0,0 +1,495 @@
+package corellium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CorelliumV1InstanceSensorsResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1InstanceSensorsResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1InstanceSensorsResource{}
+)
+
+// NewCorelliumV1InstanceSensorsResource is a helper function to simplify the provider implementation.
+func NewCorelliumV1InstanceSensorsResource() resource.Resource {
+	return &CorelliumV1InstanceSensorsResource{}
+}
+
+// CorelliumV1InstanceSensorsResource is the resource implementation. It sets the location, the battery and the sensors
+// of an instance, and sets them again when they changed, e.g. after the instance rebooted.
+type CorelliumV1InstanceSensorsResource struct {
+	client *corellium.APIClient
+}
+
+// V1InstanceSensorsLocationModel is the GPS location of the instance.
+type V1InstanceSensorsLocationModel struct {
+	Latitude  types.Float64 `tfsdk:"latitude"`
+	Longitude types.Float64 `tfsdk:"longitude"`
+	Altitude  types.Float64 `tfsdk:"altitude"`
+}
+
+// V1InstanceSensorsBatteryModel is the battery of the instance.
+type V1InstanceSensorsBatteryModel struct {
+	// Level is the battery charge, in percent.
+	Level types.Float64 `tfsdk:"level"`
+	// Charging is true when the instance is plugged to a charger.
+	Charging types.Bool `tfsdk:"charging"`
+}
+
+// V1InstanceSensorsModel maps the resource schema data. Only the values that are set are managed.
+type V1InstanceSensorsModel struct {
+	Id       types.String                    `tfsdk:"id"`
+	Instance types.String                    `tfsdk:"instance"`
+	Location *V1InstanceSensorsLocationModel `tfsdk:"location"`
+	Battery  *V1InstanceSensorsBatteryModel  `tfsdk:"battery"`
+	// Acceleration, Gyroscope, Magnetic and Orientation are the X, Y and Z values of the sensor.
+	Acceleration types.List    `tfsdk:"acceleration"`
+	Gyroscope    types.List    `tfsdk:"gyroscope"`
+	Magnetic     types.List    `tfsdk:"magnetic"`
+	Orientation  types.List    `tfsdk:"orientation"`
+	Temperature  types.Float64 `tfsdk:"temperature"`
+	Proximity    types.Float64 `tfsdk:"proximity"`
+	Light        types.Float64 `tfsdk:"light"`
+	Pressure     types.Float64 `tfsdk:"pressure"`
+	Humidity     types.Float64 `tfsdk:"humidity"`
+}
+
+// Metadata returns the resource type name.
+func (d *CorelliumV1InstanceSensorsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1instance_sensors"
+}
+
+// Schema defines the schema for the resource.
+func (d *CorelliumV1InstanceSensorsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	vector := func(description string) schema.ListAttribute {
+		return schema.ListAttribute{
+			Description: description + ", the X, Y and Z values",
+			Optional:    true,
+			ElementType: types.Float64Type,
+			Validators: []validator.List{
+				listvalidator.SizeBetween(3, 3),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Sensors ID, the instance ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance ID",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"location": schema.SingleNestedAttribute{
+				Description: "GPS location",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"latitude": schema.Float64Attribute{
+						Description: "Latitude, in degrees",
+						Required:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(-90, 90),
+						},
+					},
+					"longitude": schema.Float64Attribute{
+						Description: "Longitude, in degrees",
+						Required:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(-180, 180),
+						},
+					},
+					"altitude": schema.Float64Attribute{
+						Description: "Altitude, in meters",
+						Optional:    true,
+					},
+				},
+			},
+			"battery": schema.SingleNestedAttribute{
+				Description: "Battery",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"level": schema.Float64Attribute{
+						Description: "Battery charge, in percent",
+						Required:    true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 100),
+						},
+					},
+					"charging": schema.BoolAttribute{
+						Description: "Battery charging",
+						Optional:    true,
+					},
+				},
+			},
+			"acceleration": vector("Accelerometer"),
+			"gyroscope":    vector("Gyroscope"),
+			"magnetic":     vector("Magnetometer"),
+			"orientation":  vector("Orientation"),
+			"temperature": schema.Float64Attribute{
+				Description: "Temperature, in degrees Celsius",
+				Optional:    true,
+			},
+			"proximity": schema.Float64Attribute{
+				Description: "Proximity, in centimeters",
+				Optional:    true,
+			},
+			"light": schema.Float64Attribute{
+				Description: "Ambient light, in lux",
+				Optional:    true,
+			},
+			"pressure": schema.Float64Attribute{
+				Description: "Air pressure, in hectopascals",
+				Optional:    true,
+			},
+			"humidity": schema.Float64Attribute{
+				Description: "Relative humidity, in percent",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (d *CorelliumV1InstanceSensorsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan V1InstanceSensorsModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.set(ctx, plan, "Error creating sensors")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = plan.Instance
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the values of the instance. A value that changed, e.g. because the instance
+// rebooted, is planned to be set again.
+func (d *CorelliumV1InstanceSensorsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state V1InstanceSensorsModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	data, r, err := V1GetInstancePeripheralsManual(auth, d.client.GetConfig(), state.Instance.ValueString())
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The instance was deleted outside of Terraform, so the sensors are removed from the state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		if errors.Is(apiErr, ErrConflict) {
+			// NOTICE: The sensors of an instance that isn't on can't be read, so they are kept as they are, and read
+			// again once it is on.
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Unable to read sensors", "An unexpected error was encountered trying to read the peripherals of the instance", apiErr)
+		return
+	}
+
+	if state.Location != nil {
+		if data.GPSLatitude == nil || data.GPSLongitude == nil {
+			state.Location = nil
+		} else {
+			state.Location.Latitude = refreshFloat64(state.Location.Latitude, data.GPSLatitude)
+			state.Location.Longitude = refreshFloat64(state.Location.Longitude, data.GPSLongitude)
+			state.Location.Altitude = refreshFloat64(state.Location.Altitude, data.GPSAltitude)
+		}
+	}
+
+	if state.Battery != nil {
+		if data.BatteryCapacity == nil {
+			state.Battery = nil
+		} else {
+			state.Battery.Level = refreshFloat64(state.Battery.Level, data.BatteryCapacity)
+			if !state.Battery.Charging.IsNull() {
+				state.Battery.Charging = types.BoolPointerValue(data.ACOnline)
+			}
+		}
+	}
+
+	for _, v := range []struct {
+		prior *types.List
+		value []float64
+	}{
+		{&state.Acceleration, data.Acceleration},
+		{&state.Gyroscope, data.Gyroscope},
+		{&state.Magnetic, data.Magnetic},
+		{&state.Orientation, data.Orientation},
+	} {
+		refreshed, diags := refreshFloat64List(ctx, *v.prior, v.value)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		*v.prior = refreshed
+	}
+
+	state.Temperature = refreshFloat64(state.Temperature, data.Temperature)
+	state.Proximity = refreshFloat64(state.Proximity, data.Proximity)
+	state.Light = refreshFloat64(state.Light, data.Light)
+	state.Pressure = refreshFloat64(state.Pressure, data.Pressure)
+	state.Humidity = refreshFloat64(state.Humidity, data.Humidity)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update sets the values of the instance again.
+func (d *CorelliumV1InstanceSensorsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan V1InstanceSensorsModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.set(ctx, plan, "Error updating sensors")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the sensors from the Terraform state.
+// NOTICE: The API can't reset the peripherals of an instance, so the instance keeps the values until it is rebooted.
+func (d *CorelliumV1InstanceSensorsResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ImportState imports the sensors of an instance into the Terraform state using the instance ID.
+func (d *CorelliumV1InstanceSensorsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), req.ID)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (d *CorelliumV1InstanceSensorsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
+
+// set sets the values of the model on the instance. The values that aren't set are left as they are.
+func (d *CorelliumV1InstanceSensorsResource) set(ctx context.Context, m V1InstanceSensorsModel, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data := V1PeripheralsDataManual{
+		Temperature: m.Temperature.ValueFloat64Pointer(),
+		Proximity:   m.Proximity.ValueFloat64Pointer(),
+		Light:       m.Light.ValueFloat64Pointer(),
+		Pressure:    m.Pressure.ValueFloat64Pointer(),
+		Humidity:    m.Humidity.ValueFloat64Pointer(),
+	}
+
+	if m.Location != nil {
+		data.GPSLatitude = m.Location.Latitude.ValueFloat64Pointer()
+		data.GPSLongitude = m.Location.Longitude.ValueFloat64Pointer()
+		data.GPSAltitude = m.Location.Altitude.ValueFloat64Pointer()
+	}
+
+	if m.Battery != nil {
+		data.BatteryCapacity = m.Battery.Level.ValueFloat64Pointer()
+		data.ACOnline = m.Battery.Charging.ValueBoolPointer()
+	}
+
+	for _, v := range []struct {
+		list  types.List
+		value *[]float64
+	}{
+		{m.Acceleration, &data.Acceleration},
+		{m.Gyroscope, &data.Gyroscope},
+		{m.Magnetic, &data.Magnetic},
+		{m.Orientation, &data.Orientation},
+	} {
+		if v.list.IsNull() {
+			continue
+		}
+
+		diags.Append(v.list.ElementsAs(ctx, v.value, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	_, r, err := V1SetInstancePeripheralsManual(auth, d.client.GetConfig(), m.Instance.ValueString(), data)
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrConflict) {
+			addAPIError(&diags, summary, "The sensors can only be set while the instance is on", apiErr)
+			return diags
+		}
+
+		addAPIError(&diags, summary, "An unexpected error was encountered trying to set the peripherals of the instance", apiErr)
+		return diags
+	}
+
+	return diags
+}
+
+// refreshFloat64 returns the value read from the API when the prior value is set. The prior value is kept when both
+// are the same as float32, the precision the API may store them with, so they don't drift.
+func refreshFloat64(prior types.Float64, v *float64) types.Float64 {
+	if prior.IsNull() {
+		return prior
+	}
+
+	if v == nil {
+		return types.Float64Null()
+	}
+
+	if float32(prior.ValueFloat64()) == float32(*v) {
+		return prior
+	}
+
+	return types.Float64Value(*v)
+}
+
+// refreshFloat64List returns the values read from the API when the prior list is set, keeping the prior list when
+// they are the same as float32.
+func refreshFloat64List(ctx context.Context, prior types.List, v []float64) (types.List, diag.Diagnostics) {
+	if prior.IsNull() {
+		return prior, nil
+	}
+
+	if v == nil {
+		return types.ListNull(types.Float64Type), nil
+	}
+
+	var values []float64
+	diags := prior.ElementsAs(ctx, &values, false)
+	if diags.HasError() {
+		return prior, diags
+	}
+
+	if len(values) == len(v) {
+		same := true
+		for n := range values {
+			if float32(values[n]) != float32(v[n]) {
+				same = false
+				break
+			}
+		}
+
+		if same {
+			return prior, diags
+		}
+	}
+
+	return types.ListValueFrom(ctx, types.Float64Type, v)
+}
+
+// V1PeripheralsDataManual is the peripherals data of an instance. It has the fields of corellium.PeripheralsData, and
+// the GPS and battery fields the API client doesn't have.
+type V1PeripheralsDataManual struct {
+	Acceleration []float64 `json:"acceleration,omitempty"`
+	Gyroscope    []float64 `json:"gyroscope,omitempty"`
+	Magnetic     []float64 `json:"magnetic,omitempty"`
+	Orientation  []float64 `json:"orientation,omitempty"`
+	Temperature  *float64  `json:"temperature,omitempty"`
+	Proximity    *float64  `json:"proximity,omitempty"`
+	Light        *float64  `json:"light,omitempty"`
+	Pressure     *float64  `json:"pressure,omitempty"`
+	Humidity     *float64  `json:"humidity,omitempty"`
+	GPSLatitude  *float64  `json:"gpsLat,omitempty"`
+	GPSLongitude *float64  `json:"gpsLon,omitempty"`
+	GPSAltitude  *float64  `json:"gpsAlt,omitempty"`
+	// BatteryCapacity is the battery charge, in percent.
+	BatteryCapacity *float64 `json:"batteryCapacity,omitempty"`
+	// ACOnline is true when the instance is plugged to a charger.
+	ACOnline *bool `json:"acOnline,omitempty"`
+}
+
+// V1GetInstancePeripheralsManual gets the peripherals of the instance, with the GPS and battery fields the API client
+// doesn't have.
+func V1GetInstancePeripheralsManual(ctx context.Context, cfg *corellium.Configuration, instanceId string) (*V1PeripheralsDataManual, *http.Response, error) {
+	return v1InstancePeripheralsManual(ctx, cfg, http.MethodGet, instanceId, nil)
+}
+
+// V1SetInstancePeripheralsManual sets the peripherals of the instance, with the GPS and battery fields the API client
+// doesn't have.
+func V1SetInstancePeripheralsManual(ctx context.Context, cfg *corellium.Configuration, instanceId string, data V1PeripheralsDataManual) (*V1PeripheralsDataManual, *http.Response, error) {
+	return v1InstancePeripheralsManual(ctx, cfg, http.MethodPut, instanceId, &data)
+}
+
+// v1InstancePeripheralsManual sends the request to the peripherals endpoint of the instance.
+func v1InstancePeripheralsManual(ctx context.Context, cfg *corellium.Configuration, method, instanceId string, data *V1PeripheralsDataManual) (*V1PeripheralsDataManual, *http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		body = bytes.NewReader(payload)
+	}
+
+	b, resp, err := doManualRequest(ctx, cfg, method, "/api/v1/instances/"+url.PathEscape(instanceId)+"/peripherals", body)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var peripherals V1PeripheralsDataManual
+	if err := json.Unmarshal(b, &peripherals); err != nil {
+		return nil, resp, err
+	}
+
+	return &peripherals, resp, nil
+}
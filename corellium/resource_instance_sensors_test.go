@@ -0,0 +1,125 @@
+package corellium
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCorelliumV1InstanceSensorsResource(t *testing.T) {
+	config := func(latitude float64, level int) string {
+		return providerConfig + fmt.Sprintf(`
+        resource "corellium_v1project" "test" {
+            name = "`+t.Name()+`"
+            settings = {
+                version = 1
+                internet_access = true
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+
+        resource "corellium_v1instance" "test" {
+            name = "test-sensors"
+            flavor = "ranchu"
+            project = corellium_v1project.test.id
+            os = "13.0.0"
+            wait_for = "state"
+        }
+
+        resource "corellium_v1instance_sensors" "test" {
+            instance = corellium_v1instance.test.id
+            location = {
+                latitude = %v
+                longitude = -122.4194
+            }
+            battery = {
+                level = %d
+                charging = true
+            }
+            acceleration = [0, 9.81, 0]
+            temperature = 21.5
+        }
+        `, latitude, level)
+	}
+
+	var instanceId string
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttrPair("corellium_v1instance_sensors.test", "id", "corellium_v1instance.test", "id"),
+		resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "location.longitude", "-122.4194"),
+		resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "battery.charging", "true"),
+		resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "acceleration.#", "3"),
+		resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "acceleration.1", "9.81"),
+		resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "temperature", "21.5"),
+		resource.TestCheckNoResourceAttr("corellium_v1instance_sensors.test", "humidity"),
+		func(s *terraform.State) error {
+			instanceId = s.RootModule().Resources["corellium_v1instance.test"].Primary.ID
+			return nil
+		},
+	}
+
+	// testAccCheckPeripherals checks the values were set on the instance of the mock.
+	testAccCheckPeripherals := func(latitude float64, level int) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			if testAccMock == nil {
+				return nil
+			}
+
+			peripherals := testAccMock.Peripherals(instanceId)
+			if peripherals["gpsLat"] != latitude {
+				return fmt.Errorf("expected latitude %v, got %v", latitude, peripherals["gpsLat"])
+			}
+			if peripherals["batteryCapacity"] != float64(level) {
+				return fmt.Errorf("expected battery level %v, got %v", level, peripherals["batteryCapacity"])
+			}
+			if peripherals["acOnline"] != true {
+				return fmt.Errorf("expected charging battery, got %v", peripherals["acOnline"])
+			}
+
+			return nil
+		}
+	}
+
+	steps := []resource.TestStep{
+		{
+			Config: config(37.7749, 80),
+			Check: resource.ComposeTestCheckFunc(append(checks,
+				resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "location.latitude", "37.7749"),
+				resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "battery.level", "80"),
+				testAccCheckPeripherals(37.7749, 80),
+			)...),
+		},
+		{
+			Config: config(48.8566, 15),
+			Check: resource.ComposeTestCheckFunc(append(checks,
+				resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "location.latitude", "48.8566"),
+				resource.TestCheckResourceAttr("corellium_v1instance_sensors.test", "battery.level", "15"),
+				testAccCheckPeripherals(48.8566, 15),
+			)...),
+		},
+	}
+	if testAccMock != nil {
+		// The peripherals of an instance are reset when it reboots, so they are set again.
+		steps = append(steps, resource.TestStep{
+			PreConfig: func() {
+				testAccMock.ResetPeripherals(instanceId)
+			},
+			Config: config(48.8566, 15),
+			Check: resource.ComposeTestCheckFunc(append(checks,
+				testAccCheckPeripherals(48.8566, 15),
+			)...),
+		})
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+}
@@ -1,6 +1,10 @@
 package corellium
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -9,7 +13,7 @@ import (
 func TestAccCorelliumV1InstanceResource_basic(t *testing.T) {
 	projectConfig := `
     resource "corellium_v1project" "test" {
-        name = "test"
+        name = "` + t.Name() + `"
         settings = {
             version = 1
             internet_access = false
@@ -24,7 +28,7 @@ func TestAccCorelliumV1InstanceResource_basic(t *testing.T) {
     }
     `
 
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
@@ -57,6 +61,42 @@ func TestAccCorelliumV1InstanceResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("corellium_v1instance.test", "os", "15.7.5"),
 				),
 			},
+			{
+				Config: providerConfig + projectConfig + `
+                resource "corellium_v1instance" "test" {
+                    name = "test_update"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    state = "off"
+                    wait_for_ready = true
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "off"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + `
+                resource "corellium_v1instance" "test" {
+                    name = "test_update"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    state = "on"
+                    wait_for_ready = true
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "on"),
+				),
+			},
+			{
+				ResourceName:            "corellium_v1instance.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_ready"},
+			},
 		},
 	})
 }
@@ -64,7 +104,7 @@ func TestAccCorelliumV1InstanceResource_basic(t *testing.T) {
 func TestAccCorelliumV1InstanceResource_wait_for_ready(t *testing.T) {
 	projectConfig := `
     resource "corellium_v1project" "test" {
-        name = "test"
+        name = "` + t.Name() + `"
         settings = {
             version = 1
             internet_access = false
@@ -79,7 +119,7 @@ func TestAccCorelliumV1InstanceResource_wait_for_ready(t *testing.T) {
     }
     `
 
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
@@ -90,7 +130,9 @@ func TestAccCorelliumV1InstanceResource_wait_for_ready(t *testing.T) {
                     project = corellium_v1project.test.id
                     os = "13.0.0"
                     wait_for_ready = true
-                    wait_for_ready_timeout = 600
+                    timeouts {
+                        create = "10m"
+                    }
                 }
                 `,
 				Check: resource.ComposeTestCheckFunc(
@@ -103,8 +145,385 @@ func TestAccCorelliumV1InstanceResource_wait_for_ready(t *testing.T) {
 	})
 }
 
+func TestAccCorelliumV1InstanceResource_state(t *testing.T) {
+	projectConfig := `
+    resource "corellium_v1project" "test" {
+        name = "` + t.Name() + `"
+        settings = {
+            version = 1
+            internet_access = false
+            dhcp = false
+        }
+        quotas = {
+            cores = 2
+        }
+        users = []
+        teams = []
+        keys  = []
+    }
+    `
+
+	instanceConfig := func(state string) string {
+		return fmt.Sprintf(`
+        resource "corellium_v1instance" "test" {
+            name = "test"
+            flavor = "iphone7plus"
+            project = corellium_v1project.test.id
+            os = "15.7.5"
+            state = "%s"
+            timeouts {
+                create = "10m"
+            }
+        }
+        `, state)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + projectConfig + instanceConfig("paused"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "paused"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig("on"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "on"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig("paused"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "paused"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig("on"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "on"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceResource_state_off(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+                resource "corellium_v1project" "test" {
+                    name = "` + t.Name() + `"
+                    settings = {
+                        version = 1
+                        internet_access = false
+                        dhcp = false
+                    }
+                    quotas = {
+                        cores = 2
+                    }
+                    users = []
+                    teams = []
+                    keys  = []
+                }
+
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    state = "off"
+                    timeouts {
+                        create = "10m"
+                    }
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "off"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceResource_wait_for_ready_timeout(t *testing.T) {
+	projectConfig := `
+    resource "corellium_v1project" "test" {
+        name = "` + t.Name() + `"
+        settings = {
+            version = 1
+            internet_access = false
+            dhcp = false
+        }
+        quotas = {
+            cores = 6
+        }
+        users = []
+        teams = []
+        keys  = []
+    }
+    `
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// NOTICE: The deprecated wait_for_ready_timeout is still accepted, as the create timeout.
+				Config: providerConfig + projectConfig + `
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "samsung-galaxy-s-duos"
+                    project = corellium_v1project.test.id
+                    os = "13.0.0"
+                    wait_for_ready = true
+                    wait_for_ready_timeout = 600
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "wait_for_ready_timeout", "600"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "state", "on"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceResource_create_options(t *testing.T) {
+	projectConfig := `
+    resource "corellium_v1project" "test" {
+        name = "` + t.Name() + `"
+        settings = {
+            version = 1
+            internet_access = false
+            dhcp = false
+        }
+        quotas = {
+            cores = 2
+        }
+        users = []
+        teams = []
+        keys  = []
+    }
+    `
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + projectConfig + `
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    patches = ["nonjailbroken"]
+                    boot_options = {
+                        udid = "0000000000000000000000000000000000000000"
+                        additional_tags = ["nodevmode"]
+                    }
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "patches.#", "1"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "patches.0", "nonjailbroken"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "boot_options.udid", "0000000000000000000000000000000000000000"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "boot_options.additional_tags.0", "nodevmode"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceResource_port_forward(t *testing.T) {
+	projectConfig := `
+    resource "corellium_v1project" "test" {
+        name = "` + t.Name() + `"
+        settings = {
+            version = 1
+            internet_access = false
+            dhcp = false
+        }
+        quotas = {
+            cores = 2
+        }
+        users = []
+        teams = []
+        keys  = []
+    }
+    `
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + projectConfig + `
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    port_forward = [
+                        {
+                            device_port = 22
+                            router_port = 2222
+                        },
+                        {
+                            device_port = 5555
+                        },
+                    ]
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "port_forward.#", "2"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "port_forward_endpoints.%", "2"),
+					resource.TestMatchResourceAttr("corellium_v1instance.test", "port_forward_endpoints.22", regexp.MustCompile(`:2222$`)),
+					resource.TestCheckResourceAttrSet("corellium_v1instance.test", "port_forward_endpoints.5555"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + `
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                    port_forward = [
+                        {
+                            device_port = 22
+                            router_port = 2223
+                            expose = true
+                        },
+                    ]
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "port_forward.#", "1"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "port_forward_endpoints.%", "1"),
+					resource.TestMatchResourceAttr("corellium_v1instance.test", "port_forward_endpoints.22", regexp.MustCompile(`:2223$`)),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "services.vpn.proxy.0.expose", "true"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + `
+                resource "corellium_v1instance" "test" {
+                    name = "test"
+                    flavor = "iphone7plus"
+                    project = corellium_v1project.test.id
+                    os = "15.7.5"
+                }
+                `,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("corellium_v1instance.test", "port_forward"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "port_forward_endpoints.%", "0"),
+					resource.TestCheckResourceAttr("corellium_v1instance.test", "services.vpn.proxy.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCorelliumV1InstanceResource_custom_images(t *testing.T) {
+	if testAccMock == nil {
+		t.Skip("The tests don't have kernel and ramdisk images to boot a real instance with.")
+	}
+
+	filename := filepath.Join(t.TempDir(), "image.bin")
+	if err := os.WriteFile(filename, []byte("image"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(images string) string {
+		config := providerConfig
+		for _, project := range []string{"test", "other"} {
+			config += fmt.Sprintf(`
+            resource "corellium_v1project" %[1]q {
+                name = "%[2]s_%[1]s"
+                settings = {
+                    version = 1
+                    internet_access = false
+                    dhcp = false
+                }
+                quotas = {
+                    cores = 2
+                }
+                users = []
+                teams = []
+                keys  = []
+            }
+            `, project, t.Name())
+		}
+
+		for name, image := range map[string][2]string{
+			"kernel":  {"kernel", "test"},
+			"ramdisk": {"ramdisk", "test"},
+			"other":   {"kernel", "other"},
+		} {
+			config += fmt.Sprintf(`
+            resource "corellium_v1image" %q {
+                name = %[1]q
+                type = %q
+                filename = %q
+                encapsulated = false
+                project = corellium_v1project.%s.id
+            }
+            `, name, image[0], filename, image[1])
+		}
+
+		return config + `
+        resource "corellium_v1instance" "test" {
+            name = "test-custom-images"
+            flavor = "ranchu"
+            project = corellium_v1project.test.id
+            os = "13.0.0"
+        ` + images + `
+        }
+        `
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+                custom_kernel = corellium_v1image.kernel.id
+                custom_ramdisk = corellium_v1image.ramdisk.id
+                `),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("corellium_v1instance.test", "custom_kernel", "corellium_v1image.kernel", "id"),
+					resource.TestCheckResourceAttrPair("corellium_v1instance.test", "custom_ramdisk", "corellium_v1image.ramdisk", "id"),
+					resource.TestCheckNoResourceAttr("corellium_v1instance.test", "custom_devicetree"),
+				),
+			},
+			{
+				Config: config(`
+                custom_kernel = corellium_v1image.ramdisk.id
+                `),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid image type"),
+			},
+			{
+				Config: config(`
+                custom_kernel = corellium_v1image.other.id
+                `),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid image project"),
+			},
+			{
+				Config: config(`
+                custom_devicetree = "00000000-0000-4000-0000-000000000000"
+                `),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Image not found"),
+			},
+		},
+	})
+}
+
 func TestAccCorelliumV1InstanceResource_default_project(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
@@ -126,7 +545,7 @@ func TestAccCorelliumV1InstanceResource_default_project(t *testing.T) {
 }
 
 func TestAccCorelliumV1InstanceResource_non_enterprise(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
@@ -0,0 +1,463 @@
+package corellium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-corellium/corellium/pkg/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CorelliumV1InstanceTraceResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1InstanceTraceResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1InstanceTraceResource{}
+)
+
+// NewCorelliumV1InstanceTraceResource is a helper function to simplify the provider implementation.
+func NewCorelliumV1InstanceTraceResource() resource.Resource {
+	return &CorelliumV1InstanceTraceResource{}
+}
+
+// CorelliumV1InstanceTraceResource is the resource implementation. It configures CoreTrace, the system call tracer of
+// an instance, and downloads its log when it is destroyed.
+type CorelliumV1InstanceTraceResource struct {
+	client *corellium.APIClient
+}
+
+// V1InstanceTraceFilterModel is the filter of the traced threads. A thread is traced when it matches any of the
+// values, or every thread is traced when there isn't any.
+type V1InstanceTraceFilterModel struct {
+	Pids  []types.Int64  `tfsdk:"pids"`
+	Names []types.String `tfsdk:"names"`
+	Tids  []types.Int64  `tfsdk:"tids"`
+}
+
+// V1InstanceTraceModel maps the resource schema data.
+type V1InstanceTraceModel struct {
+	Id       types.String                `tfsdk:"id"`
+	Instance types.String                `tfsdk:"instance"`
+	Filter   *V1InstanceTraceFilterModel `tfsdk:"filter"`
+	// Enabled is true when CoreTrace is started.
+	Enabled types.Bool `tfsdk:"enabled"`
+	// OutputPath is the local path the log is written to when the resource is destroyed, when it is set.
+	OutputPath types.String `tfsdk:"output_path"`
+	// StartedAt is the time the instance was started when CoreTrace was configured. CoreTrace stops when the instance
+	// restarts, so it's configured again once the instance was started at another time.
+	StartedAt types.String `tfsdk:"started_at"`
+}
+
+// Metadata returns the resource type name.
+func (d *CorelliumV1InstanceTraceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_v1instance_trace"
+}
+
+// Schema defines the schema for the resource.
+func (d *CorelliumV1InstanceTraceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Trace ID, the instance ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance": schema.StringAttribute{
+				Description: "Instance ID",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filter": schema.SingleNestedAttribute{
+				Description: "Traced threads. Every thread is traced when it isn't set",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"pids": schema.ListAttribute{
+						Description: "Process IDs",
+						Optional:    true,
+						ElementType: types.Int64Type,
+					},
+					"names": schema.ListAttribute{
+						Description: "Process or thread names",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"tids": schema.ListAttribute{
+						Description: "Thread IDs",
+						Optional:    true,
+						ElementType: types.Int64Type,
+					},
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "CoreTrace started. Default is true",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"output_path": schema.StringAttribute{
+				Description: "Local path the log is written to when the resource is destroyed",
+				Optional:    true,
+			},
+			"started_at": schema.StringAttribute{
+				Description: "Time the instance was started when CoreTrace was configured",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (d *CorelliumV1InstanceTraceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan V1InstanceTraceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.configure(ctx, plan, false, "Error creating trace")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = plan.Instance
+
+	startedAt, diags := d.startedAt(ctx, plan.Instance.ValueString(), "Error creating trace")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.StartedAt = startedAt
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data. A trace of an instance that restarted, what stops CoreTrace,
+// is planned to be started again.
+// NOTICE: The API doesn't return the filter nor whether CoreTrace is started, so only the instance is checked.
+func (d *CorelliumV1InstanceTraceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state V1InstanceTraceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	instance, r, err := d.client.InstancesApi.V1GetInstance(auth, state.Instance.ValueString()).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The instance was deleted outside of Terraform, so the trace is removed from the state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Unable to read trace", "An unexpected error was encountered trying to read the instance", apiErr)
+		return
+	}
+
+	// NOTICE: An imported trace doesn't know if it is started, so it gets the default.
+	if state.Enabled.IsNull() {
+		state.Enabled = types.BoolValue(true)
+	}
+
+	// NOTICE: The instance restarted since CoreTrace was configured, so CoreTrace is stopped, and it's saved as
+	// stopped to be started again.
+	startedAt := types.StringValue(instance.GetStartedAt())
+	if !state.StartedAt.IsNull() && !state.StartedAt.Equal(startedAt) {
+		state.Enabled = types.BoolValue(false)
+	}
+	state.StartedAt = startedAt
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (d *CorelliumV1InstanceTraceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state V1InstanceTraceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// NOTICE: A change of output_path alone doesn't change the trace, so it is only saved.
+	if state.Enabled.Equal(plan.Enabled) && traceFilterEqual(state.Filter, plan.Filter) {
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(d.configure(ctx, plan, state.Enabled.ValueBool(), "Error updating trace")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	startedAt, diags := d.startedAt(ctx, plan.Instance.ValueString(), "Error updating trace")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.StartedAt = startedAt
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete downloads the log to the output path, if any, stops CoreTrace and removes its filter.
+// NOTICE: The log is kept on the instance, so it can still be downloaded when the resource is destroyed.
+func (d *CorelliumV1InstanceTraceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state V1InstanceTraceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	instanceId := state.Instance.ValueString()
+
+	if !state.OutputPath.IsNull() {
+		log, r, err := V1DownloadCoreTraceLogManual(auth, d.client.GetConfig(), instanceId)
+		if err != nil {
+			apiErr := NewAPIError(r, err)
+			if errors.Is(apiErr, ErrNotFound) {
+				return
+			}
+
+			addAPIError(&resp.Diagnostics, "Error deleting trace", "An unexpected error was encountered trying to download the log", apiErr)
+			return
+		}
+
+		output := state.OutputPath.ValueString()
+		if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_path"),
+				"Error deleting trace",
+				"Coudn't create the directory of "+output+": "+err.Error(),
+			)
+			return
+		}
+
+		if err := os.WriteFile(output, log, 0o644); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_path"),
+				"Error deleting trace",
+				"Coudn't write the log to "+output+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	r, err := d.client.CoreTraceApi.V1StopCoreTrace(auth, instanceId).Execute()
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			return
+		}
+
+		// NOTICE: CoreTrace doesn't run while the instance isn't on, so there is nothing to stop.
+		if !errors.Is(apiErr, ErrConflict) {
+			addAPIError(&resp.Diagnostics, "Error deleting trace", "An unexpected error was encountered trying to stop CoreTrace", apiErr)
+			return
+		}
+	}
+
+	r, err = V1SetCoreTraceFilterManual(auth, d.client.GetConfig(), instanceId, []V1CoreTraceFilterManual{})
+	if err != nil {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) || errors.Is(apiErr, ErrConflict) {
+			return
+		}
+
+		addAPIError(&resp.Diagnostics, "Error deleting trace", "An unexpected error was encountered trying to remove the filter", apiErr)
+		return
+	}
+}
+
+// ImportState imports the trace of an instance into the Terraform state using the instance ID.
+func (d *CorelliumV1InstanceTraceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), req.ID)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (d *CorelliumV1InstanceTraceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*corellium.APIClient)
+}
+
+// configure sets the filter of the model, and starts or stops CoreTrace. A started CoreTrace is stopped while the
+// filter is set, so it traces the threads of the new filter once it is started again.
+func (d *CorelliumV1InstanceTraceResource) configure(ctx context.Context, m V1InstanceTraceModel, started bool, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	instanceId := m.Instance.ValueString()
+
+	// addError adds the error of the request, what is a conflict when the instance isn't on.
+	addError := func(detail string, r *http.Response, err error) {
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrConflict) {
+			addAPIError(&diags, summary, "CoreTrace can only be configured while the instance is on", apiErr)
+			return
+		}
+
+		addAPIError(&diags, summary, detail, apiErr)
+	}
+
+	if started {
+		r, err := d.client.CoreTraceApi.V1StopCoreTrace(auth, instanceId).Execute()
+		if err != nil {
+			addError("An unexpected error was encountered trying to stop CoreTrace", r, err)
+			return diags
+		}
+	}
+
+	r, err := V1SetCoreTraceFilterManual(auth, d.client.GetConfig(), instanceId, newV1CoreTraceFiltersManual(m.Filter))
+	if err != nil {
+		addError("An unexpected error was encountered trying to set the filter", r, err)
+		return diags
+	}
+
+	if m.Enabled.ValueBool() {
+		r, err := d.client.CoreTraceApi.V1StartCoreTrace(auth, instanceId).Execute()
+		if err != nil {
+			addError("An unexpected error was encountered trying to start CoreTrace", r, err)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// startedAt returns the time the instance was started, what changes when it restarts.
+func (d *CorelliumV1InstanceTraceResource) startedAt(ctx context.Context, instanceId, summary string) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+	instance, r, err := d.client.InstancesApi.V1GetInstance(auth, instanceId).Execute()
+	if err != nil {
+		addAPIError(&diags, summary, "An unexpected error was encountered trying to read the instance", NewAPIError(r, err))
+		return types.StringNull(), diags
+	}
+
+	return types.StringValue(instance.GetStartedAt()), diags
+}
+
+// traceFilterEqual returns true when the filters have the same values.
+func traceFilterEqual(a, b *V1InstanceTraceFilterModel) bool {
+	x, y := newV1CoreTraceFiltersManual(a), newV1CoreTraceFiltersManual(b)
+	if len(x) != len(y) {
+		return false
+	}
+
+	for n := range x {
+		if x[n] != y[n] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// V1CoreTraceFilterManual is a value the threads are filtered by, what the API client doesn't have.
+type V1CoreTraceFilterManual struct {
+	// Trait is what the value is matched against: pid, name or tid.
+	Trait string `json:"trait"`
+	Value string `json:"value"`
+}
+
+// newV1CoreTraceFiltersManual returns the filters of the model, the process IDs first, then the names and the thread
+// IDs.
+func newV1CoreTraceFiltersManual(m *V1InstanceTraceFilterModel) []V1CoreTraceFilterManual {
+	filters := []V1CoreTraceFilterManual{}
+	if m == nil {
+		return filters
+	}
+
+	for _, pid := range m.Pids {
+		filters = append(filters, V1CoreTraceFilterManual{Trait: "pid", Value: strconv.FormatInt(pid.ValueInt64(), 10)})
+	}
+	for _, name := range m.Names {
+		filters = append(filters, V1CoreTraceFilterManual{Trait: "name", Value: name.ValueString()})
+	}
+	for _, tid := range m.Tids {
+		filters = append(filters, V1CoreTraceFilterManual{Trait: "tid", Value: strconv.FormatInt(tid.ValueInt64(), 10)})
+	}
+
+	return filters
+}
+
+// V1SetCoreTraceFilterManual sets the filter of the threads CoreTrace traces on the instance, what the API client
+// doesn't have an endpoint for. An empty filter traces every thread.
+func V1SetCoreTraceFilterManual(ctx context.Context, cfg *corellium.Configuration, instanceId string, filters []V1CoreTraceFilterManual) (*http.Response, error) {
+	payload, err := json.Marshal(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	_, resp, err := v1CoreTraceManual(ctx, cfg, http.MethodPut, instanceId, bytes.NewReader(payload))
+	return resp, err
+}
+
+// V1DownloadCoreTraceLogManual downloads the CoreTrace log of the instance, what the API client doesn't have an
+// endpoint for.
+func V1DownloadCoreTraceLogManual(ctx context.Context, cfg *corellium.Configuration, instanceId string) ([]byte, *http.Response, error) {
+	return v1CoreTraceManual(ctx, cfg, http.MethodGet, instanceId, nil)
+}
+
+// v1CoreTraceManual sends the request to the CoreTrace endpoint of the instance, and returns the response body.
+func v1CoreTraceManual(ctx context.Context, cfg *corellium.Configuration, method, instanceId string, body io.Reader) ([]byte, *http.Response, error) {
+	return doManualRequest(ctx, cfg, method, "/api/v1/instances/"+url.PathEscape(instanceId)+"/strace", body)
+}
@@ -0,0 +1,147 @@
+package corellium
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"terraform-provider-corellium/corellium/pkg/mock"
+)
+
+func TestAccCorelliumV1InstanceTraceResource(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "coretrace.log")
+
+	config := func(trace string) string {
+		return providerConfig + fmt.Sprintf(`
+        resource "corellium_v1project" "test" {
+            name = "`+t.Name()+`"
+            settings = {
+                version = 1
+                internet_access = true
+                dhcp = false
+            }
+            quotas = {
+                cores = 2
+            }
+            users = []
+            teams = []
+            keys  = []
+        }
+
+        resource "corellium_v1instance" "test" {
+            name = "test-trace"
+            flavor = "ranchu"
+            project = corellium_v1project.test.id
+            os = "13.0.0"
+            wait_for = "state"
+        }
+
+        resource "corellium_v1instance_trace" "test" {
+            instance = corellium_v1instance.test.id
+            output_path = %q
+            %s
+        }
+        `, output, trace)
+	}
+
+	// instanceId is the ID of the instance, once it is created.
+	var instanceId string
+
+	// testAccCheckCoreTrace checks CoreTrace on the instance of the mock.
+	testAccCheckCoreTrace := func(started bool, filters ...mock.CoreTraceFilter) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			if testAccMock == nil {
+				return nil
+			}
+
+			instanceId = s.RootModule().Resources["corellium_v1instance.test"].Primary.ID
+
+			gotStarted, gotFilters := testAccMock.CoreTrace(instanceId)
+			if gotStarted != started {
+				return fmt.Errorf("expected CoreTrace started %t, got %t", started, gotStarted)
+			}
+			if fmt.Sprint(gotFilters) != fmt.Sprint(filters) {
+				return fmt.Errorf("expected filter %v, got %v", filters, gotFilters)
+			}
+
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+                filter = {
+                    pids = [1]
+                    names = ["launchd"]
+                }
+                `),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("corellium_v1instance_trace.test", "id", "corellium_v1instance.test", "id"),
+					resource.TestCheckResourceAttr("corellium_v1instance_trace.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("corellium_v1instance_trace.test", "filter.pids.0", "1"),
+					resource.TestCheckResourceAttr("corellium_v1instance_trace.test", "filter.names.0", "launchd"),
+					testAccCheckCoreTrace(true, mock.CoreTraceFilter{Trait: "pid", Value: "1"}, mock.CoreTraceFilter{Trait: "name", Value: "launchd"}),
+				),
+			},
+			{
+				Config: config(`
+                enabled = false
+                filter = {
+                    tids = [42]
+                }
+                `),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_trace.test", "enabled", "false"),
+					resource.TestCheckNoResourceAttr("corellium_v1instance_trace.test", "filter.pids"),
+					testAccCheckCoreTrace(false, mock.CoreTraceFilter{Trait: "tid", Value: "42"}),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_trace.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("corellium_v1instance_trace.test", "started_at"),
+					testAccCheckCoreTrace(true),
+				),
+			},
+			{
+				// CoreTrace stops when the instance restarts, so it is started again.
+				PreConfig: func() {
+					if testAccMock != nil {
+						testAccMock.RestartInstance(instanceId)
+					}
+				},
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1instance_trace.test", "enabled", "true"),
+					testAccCheckCoreTrace(true),
+				),
+			},
+			{
+				ResourceName:            "corellium_v1instance_trace.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"output_path"},
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			log, err := os.ReadFile(output)
+			if err != nil {
+				return err
+			}
+
+			if testAccMock != nil && !bytes.Equal(log, mock.CoreTraceLog) {
+				return fmt.Errorf("expected log %q, got %q", mock.CoreTraceLog, log)
+			}
+
+			return nil
+		},
+	})
+}
@@ -1,15 +1,19 @@
 package corellium
 
 import (
+	"bytes"
 	"context"
-	"io"
+	"encoding/json"
+	"errors"
 	"math/big"
 	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
 	"github.com/aimoda/go-corellium-api-client"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -19,8 +23,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &CorelliumV1ProjectResource{}
-	_ resource.ResourceWithConfigure = &CorelliumV1ProjectResource{}
+	_ resource.Resource                = &CorelliumV1ProjectResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1ProjectResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1ProjectResource{}
 )
 
 // NewCorelliumV1ProjectResource is a helper function to simplify the provider implementation.
@@ -305,14 +310,8 @@ func (d *CorelliumV1ProjectResource) Create(ctx context.Context, req resource.Cr
 	defer l.mu.Unlock()
 	projects, r, err := d.client.ProjectsApi.V1GetProjects(auth).Execute()
 	if err != nil {
-		_, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating project",
-				"Coudn't read the response body from project name check: "+err.Error(),
-			)
-			return
-		}
+		addAPIError(&resp.Diagnostics, "Error creating project", "An unexpected error was encountered trying to check the project name", NewAPIError(r, err))
+		return
 	}
 
 	for _, project := range projects {
@@ -347,27 +346,13 @@ func (d *CorelliumV1ProjectResource) Create(ctx context.Context, req resource.Cr
 
 	created, r, err := d.client.ProjectsApi.V1CreateProject(auth).Project(*p).Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Error creating project",
-				"You don't have permission to create a project.",
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error creating project", "You don't have permission to create a project", apiErr)
 			return
 		}
 
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating project",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error creating project",
-			"An unexpected error was encountered trying to create the project:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error creating project", "An unexpected error was encountered trying to create the project", apiErr)
 		return
 	}
 
@@ -381,19 +366,7 @@ func (d *CorelliumV1ProjectResource) Create(ctx context.Context, req resource.Cr
 
 	project, r, err := d.client.ProjectsApi.V1GetProject(auth, created.GetId()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error get the creatd project",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error to get created project",
-			"An unexpected error was encountered trying to create the project:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error to get created project", "An unexpected error was encountered trying to create the project", NewAPIError(r, err))
 		return
 	}
 
@@ -401,19 +374,7 @@ func (d *CorelliumV1ProjectResource) Create(ctx context.Context, req resource.Cr
 		for i, user := range plan.Users {
 			teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 			if err != nil {
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error get team with all users",
-						"Coudn't read the response body: "+err.Error(),
-					)
-					return
-				}
-
-				resp.Diagnostics.AddError(
-					"Error to get the team with all users",
-					"An unexpected error was encountered trying to get the team with all users:\n\n"+string(b),
-				)
+				addAPIError(&resp.Diagnostics, "Error to get the team with all users", "An unexpected error was encountered trying to get the team with all users", NewAPIError(r, err))
 				return
 			}
 
@@ -447,19 +408,7 @@ func (d *CorelliumV1ProjectResource) Create(ctx context.Context, req resource.Cr
 
 			r, err = d.client.RolesApi.V1AddUserRoleToProject(auth, project.GetId(), user.Id.ValueString(), user.Role.ValueString()).Execute()
 			if err != nil {
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error adding user to project",
-						"Coudn't read the response body: "+err.Error(),
-					)
-					return
-				}
-
-				resp.Diagnostics.AddError(
-					"Error adding user to project",
-					"An unexpected error was encountered trying to add user to project:\n\n"+string(b),
-				)
+				addAPIError(&resp.Diagnostics, "Error adding user to project", "An unexpected error was encountered trying to add user to project", NewAPIError(r, err))
 				return
 			}
 
@@ -471,19 +420,7 @@ func (d *CorelliumV1ProjectResource) Create(ctx context.Context, req resource.Cr
 		for i, team := range plan.Teams {
 			teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 			if err != nil {
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error get the teams",
-						"Coudn't read the response body: "+err.Error(),
-					)
-					return
-				}
-
-				resp.Diagnostics.AddError(
-					"Error to get the teams",
-					"An unexpected error was encountered trying to create the team:\n\n"+string(b),
-				)
+				addAPIError(&resp.Diagnostics, "Error to get the teams", "An unexpected error was encountered trying to create the team", NewAPIError(r, err))
 				return
 			}
 
@@ -508,19 +445,7 @@ func (d *CorelliumV1ProjectResource) Create(ctx context.Context, req resource.Cr
 
 			r, err = d.client.RolesApi.V1AddTeamRoleToProject(auth, project.GetId(), team.Id.ValueString(), team.Role.ValueString()).Execute()
 			if err != nil {
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error adding team to project",
-						"Coudn't read the response body: "+err.Error(),
-					)
-					return
-				}
-
-				resp.Diagnostics.AddError(
-					"Error adding team to project",
-					"An unexpected error was encountered trying to add team to project:\n\n"+string(b),
-				)
+				addAPIError(&resp.Diagnostics, "Error adding team to project", "An unexpected error was encountered trying to add team to project", NewAPIError(r, err))
 				return
 			}
 
@@ -530,35 +455,21 @@ func (d *CorelliumV1ProjectResource) Create(ctx context.Context, req resource.Cr
 
 	if plan.Keys != nil && len(plan.Keys) > 0 {
 		for i, key := range plan.Keys {
-			p := corellium.NewProjectKey(key.Kind.ValueString(), key.Key.ValueString())
-			projectKey, r, err := d.client.ProjectsApi.V1AddProjectKey(auth, created.Id).ProjectKey(*p).Execute()
+			p := newV1ProjectKeyParametersManual(key)
+			projectKey, r, err := V1AddProjectKeyManual(auth, d.client.GetConfig(), created.Id, p)
 			if err != nil {
-				if r.StatusCode == http.StatusForbidden {
-					resp.Diagnostics.AddError(
-						"Error creating project key",
-						"You don't have permission to create an project key in this project.",
-					)
-					return
-				}
-
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error creating project key",
-						"Coudn't read the response body: "+err.Error(),
-					)
+				apiErr := NewAPIError(r, err)
+				if errors.Is(apiErr, ErrForbidden) {
+					addAPIError(&resp.Diagnostics, "Error creating project key", "You don't have permission to create an project key in this project", apiErr)
 					return
 				}
 
-				resp.Diagnostics.AddError(
-					"Error creating project key",
-					"An unexpected error was encountered trying to create the project key:\n\n"+string(b),
-				)
+				addAPIError(&resp.Diagnostics, "Error creating project key", "An unexpected error was encountered trying to create the project key", apiErr)
 				return
 			}
 
 			plan.Keys[i].Id = types.StringValue(projectKey.GetIdentifier())
-			plan.Keys[i].Label = types.StringValue(plan.Keys[i].Label.ValueString())
+			plan.Keys[i].Label = types.StringValue(projectKey.Label)
 			plan.Keys[i].Kind = types.StringValue(projectKey.GetKind())
 			plan.Keys[i].Key = types.StringValue(projectKey.GetKey())
 			plan.Keys[i].Fingerprint = types.StringValue(projectKey.GetFingerprint())
@@ -608,38 +519,114 @@ func (d *CorelliumV1ProjectResource) Read(ctx context.Context, req resource.Read
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	project, r, err := d.client.ProjectsApi.V1GetProject(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error reading project",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The project was deleted outside of Terraform, so it's removed from the state to be created again.
+			resp.State.RemoveResource(ctx)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Unable to read project",
-			"An unexpected error was encountered trying to read the image:\n\n"+string(b))
+		addAPIError(&resp.Diagnostics, "Unable to read project", "An unexpected error was encountered trying to read the project", apiErr)
 		return
 	}
 
+	// NOTICE: When the project is being imported, the users, teams and keys aren't in the state yet, so we need to
+	// discover them from the API. Users and teams are discovered through the project's roles.
+	if state.Users == nil || state.Teams == nil {
+		roles, r, err := d.client.RolesApi.V1Roles(auth).Execute()
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error to get the project roles", "An unexpected error was encountered trying to get the project roles", NewAPIError(r, err))
+			return
+		}
+
+		teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error to get the teams", "An unexpected error was encountered trying to get the teams", NewAPIError(r, err))
+			return
+		}
+
+		users := []V1ProjectUserModel{}
+		projectTeams := []V1ProjectTeamModel{}
+		for _, role := range roles {
+			if role.GetProject() != project.GetId() {
+				continue
+			}
+
+			var found bool
+			for _, t := range teams {
+				if t.GetId() == role.GetUser() {
+					projectTeams = append(projectTeams, V1ProjectTeamModel{
+						Id:    types.StringValue(t.GetId()),
+						Label: types.StringValue(t.GetLabel()),
+						Role:  types.StringValue(role.GetRole()),
+					})
+
+					found = true
+					break
+				}
+			}
+
+			if found {
+				continue
+			}
+
+			// NOTICE: This is a workaround for the fact that the API doesn't support getting a single user by ID.
+			for _, t := range teams {
+				if t.Id == "all-users" {
+					for _, u := range t.Users {
+						if u.Id == role.GetUser() {
+							users = append(users, V1ProjectUserModel{
+								Id:    types.StringValue(u.Id),
+								Name:  types.StringValue(u.Name),
+								Label: types.StringValue(u.Label),
+								Email: types.StringValue(u.Email),
+								Role:  types.StringValue(role.GetRole()),
+							})
+
+							break
+						}
+					}
+
+					break
+				}
+			}
+		}
+
+		if state.Users == nil {
+			state.Users = users
+		}
+
+		if state.Teams == nil {
+			state.Teams = projectTeams
+		}
+	}
+
+	if state.Keys == nil {
+		projectKeys, r, err := V1GetProjectKeysManual(auth, d.client.GetConfig(), project.Id)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Unable to read project keys", "An unexpected error was encountered trying to read the project keys from the project", NewAPIError(r, err))
+			return
+		}
+
+		state.Keys = make([]V1ProjectKeyModel, len(projectKeys))
+		for i, k := range projectKeys {
+			state.Keys[i] = V1ProjectKeyModel{
+				Id:          types.StringValue(k.GetIdentifier()),
+				Label:       types.StringValue(k.Label),
+				Kind:        types.StringValue(k.GetKind()),
+				Key:         types.StringValue(k.GetKey()),
+				Fingerprint: types.StringValue(k.GetFingerprint()),
+				CreatedAt:   types.StringValue(k.GetCreatedAt().String()),
+				UpdatedAt:   types.StringValue(k.GetUpdatedAt().String()),
+			}
+		}
+	}
+
 	if state.Users != nil {
 		for i, user := range state.Users {
 			teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 			if err != nil {
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error to get team with all users",
-						"Coudn't read the response body: "+err.Error(),
-					)
-					return
-				}
-
-				resp.Diagnostics.AddError(
-					"Error to get the teams",
-					"An unexpected error was encountered trying to get the team with all users:\n\n"+string(b),
-				)
+				addAPIError(&resp.Diagnostics, "Error to get the teams", "An unexpected error was encountered trying to get the team with all users", NewAPIError(r, err))
 				return
 			}
 
@@ -678,19 +665,7 @@ func (d *CorelliumV1ProjectResource) Read(ctx context.Context, req resource.Read
 		for i, team := range state.Teams {
 			teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 			if err != nil {
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error get the teams",
-						"Coudn't read the response body: "+err.Error(),
-					)
-					return
-				}
-
-				resp.Diagnostics.AddError(
-					"Error to get the teams",
-					"An unexpected error was encountered trying to create the team:\n\n"+string(b),
-				)
+				addAPIError(&resp.Diagnostics, "Error to get the teams", "An unexpected error was encountered trying to create the team", NewAPIError(r, err))
 				return
 			}
 
@@ -718,21 +693,10 @@ func (d *CorelliumV1ProjectResource) Read(ctx context.Context, req resource.Read
 	}
 
 	if state.Keys != nil {
-		for _, key := range state.Keys {
-			projectKeys, r, err := d.client.ProjectsApi.V1GetProjectKeys(auth, project.Id).Execute()
+		for i, key := range state.Keys {
+			projectKeys, r, err := V1GetProjectKeysManual(auth, d.client.GetConfig(), project.Id)
 			if err != nil {
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error reading the project keys",
-						"Coudn't read the response body: "+err.Error(),
-					)
-					return
-				}
-
-				resp.Diagnostics.AddError(
-					"Unable to read project keys",
-					"An unexpected error was encountered trying to read the project keys from the project:\n\n"+string(b))
+				addAPIError(&resp.Diagnostics, "Unable to read project keys", "An unexpected error was encountered trying to read the project keys from the project", NewAPIError(r, err))
 				return
 			}
 
@@ -740,7 +704,7 @@ func (d *CorelliumV1ProjectResource) Read(ctx context.Context, req resource.Read
 			for _, k := range projectKeys {
 				if k.GetIdentifier() == key.Id.ValueString() {
 					key.Id = types.StringValue(k.GetIdentifier())
-					key.Label = types.StringValue(key.Label.String())
+					key.Label = types.StringValue(k.Label)
 					key.Kind = types.StringValue(k.GetKind())
 					key.Key = types.StringValue(k.GetKey())
 					key.Fingerprint = types.StringValue(k.GetFingerprint())
@@ -758,12 +722,22 @@ func (d *CorelliumV1ProjectResource) Read(ctx context.Context, req resource.Read
 				)
 				return
 			}
+
+			state.Keys[i] = key
 		}
 	}
 
 	state.Id = types.StringValue(project.GetId())
 	state.Name = types.StringValue(project.GetName())
 
+	if state.Settings == nil {
+		state.Settings = &V1ProjectSettingsModel{}
+	}
+
+	if state.Quotas == nil {
+		state.Quotas = &V1ProjectQuotasModel{}
+	}
+
 	state.Settings.Version = types.NumberValue(big.NewFloat(float64(project.Settings.GetVersion())))
 	state.Settings.InternetAccess = types.BoolValue(project.Settings.GetInternetAccess())
 	state.Settings.Dhcp = types.BoolValue(project.Settings.GetDhcp())
@@ -773,6 +747,7 @@ func (d *CorelliumV1ProjectResource) Read(ctx context.Context, req resource.Read
 	state.Quotas.Instances = types.NumberValue(big.NewFloat(float64(project.Quotas.GetInstances())))
 	state.Quotas.Ram = types.NumberValue(big.NewFloat(float64(project.Quotas.GetRam())))
 
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -822,19 +797,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	project, r, err := d.client.ProjectsApi.V1UpdateProject(auth, state.Id.ValueString()).Project(*p).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error updating project",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error updating project",
-			"An unexpected error was encountered trying to update the project:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error updating project", "An unexpected error was encountered trying to update the project", NewAPIError(r, err))
 		return
 	}
 
@@ -851,19 +814,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 			if !found {
 				r, err := d.client.RolesApi.V1RemoveUserRoleFromProject(auth, state.Id.ValueString(), user.Id.ValueString(), user.Role.ValueString()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error removing user from project",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error removing user from project",
-						"An unexpected error was encountered trying to remove user from project:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error removing user from project", "An unexpected error was encountered trying to remove user from project", NewAPIError(r, err))
 					return
 				}
 
@@ -902,19 +853,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 			if !found {
 				teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error get the teams",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error to get the teams",
-						"An unexpected error was encountered trying to get the team with all users:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error to get the teams", "An unexpected error was encountered trying to get the team with all users", NewAPIError(r, err))
 					return
 				}
 
@@ -938,19 +877,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 
 				r, err = d.client.RolesApi.V1AddUserRoleToProject(auth, state.Id.ValueString(), user.Id.ValueString(), user.Role.ValueString()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error adding user to project",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error adding user to project",
-						"An unexpected error was encountered trying to add user to project:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error adding user to project", "An unexpected error was encountered trying to add user to project", NewAPIError(r, err))
 					return
 				}
 
@@ -972,19 +899,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 			if !found {
 				r, err := d.client.RolesApi.V1RemoveTeamRoleFromProject(auth, state.Id.ValueString(), team.Id.ValueString(), team.Role.ValueString()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error removing team from project",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error removing team from project",
-						"An unexpected error was encountered trying to remove team from project:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error removing team from project", "An unexpected error was encountered trying to remove team from project", NewAPIError(r, err))
 					return
 				}
 
@@ -1023,19 +938,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 			if !found {
 				teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error get the teams",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error to get the teams",
-						"An unexpected error was encountered trying to create the team:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error to get the teams", "An unexpected error was encountered trying to create the team", NewAPIError(r, err))
 					return
 				}
 
@@ -1051,19 +954,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 
 				r, err = d.client.RolesApi.V1AddTeamRoleToProject(auth, state.Id.ValueString(), team.Id.ValueString(), team.Role.ValueString()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error adding team to project",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error adding team to project",
-						"An unexpected error was encountered trying to add team to project:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error adding team to project", "An unexpected error was encountered trying to add team to project", NewAPIError(r, err))
 					return
 				}
 
@@ -1085,19 +976,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 			if !found {
 				r, err := d.client.ProjectsApi.V1RemoveProjectKey(auth, state.Id.ValueString(), key.Id.ValueString()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error removing key from project",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error removing key from project",
-						"An unexpected error was encountered trying to remove key from project:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error removing key from project", "An unexpected error was encountered trying to remove key from project", NewAPIError(r, err))
 					return
 				}
 
@@ -1124,7 +1003,7 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 	}
 
 	if len(plan.Keys) > 0 {
-		for i, key := range plan.Keys {
+		for _, key := range plan.Keys {
 			var found bool
 			for _, k := range state.Keys {
 				if k.Id.Equal(key.Id) {
@@ -1134,28 +1013,16 @@ func (d *CorelliumV1ProjectResource) Update(ctx context.Context, req resource.Up
 			}
 
 			if !found {
-				p := corellium.NewProjectKey(key.Kind.ValueString(), key.Key.ValueString())
-				key, r, err := d.client.ProjectsApi.V1AddProjectKey(auth, state.Id.ValueString()).ProjectKey(*p).Execute()
+				p := newV1ProjectKeyParametersManual(key)
+				key, r, err := V1AddProjectKeyManual(auth, d.client.GetConfig(), state.Id.ValueString(), p)
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error adding key to project",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error adding key to project",
-						"An unexpected error was encountered trying to add key to project:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error adding key to project", "An unexpected error was encountered trying to add key to project", NewAPIError(r, err))
 					return
 				}
 
 				state.Keys = append(state.Keys, V1ProjectKeyModel{
 					Id:          types.StringValue(key.GetIdentifier()),
-					Label:       plan.Keys[i].Label,
+					Label:       types.StringValue(key.Label),
 					Kind:        types.StringValue(key.Kind),
 					Key:         types.StringValue(key.Key),
 					Fingerprint: types.StringValue(key.GetFingerprint()),
@@ -1199,22 +1066,16 @@ func (d *CorelliumV1ProjectResource) Delete(ctx context.Context, req resource.De
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	r, err := d.client.ProjectsApi.V1DeleteProject(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting project",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Unable to delete project",
-			"An unexpected error was encountered trying to delete the project:\n\n"+string(b))
+		addAPIError(&resp.Diagnostics, "Unable to delete project", "An unexpected error was encountered trying to delete the project", NewAPIError(r, err))
 		return
 	}
 }
 
+// ImportState imports an existing project into the Terraform state using its ID.
+func (d *CorelliumV1ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
 // Configure adds the provider configured client to the resource.
 func (d *CorelliumV1ProjectResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -1223,3 +1084,61 @@ func (d *CorelliumV1ProjectResource) Configure(_ context.Context, req resource.C
 
 	d.client = req.ProviderData.(*corellium.APIClient)
 }
+
+// V1ProjectKeyParametersManual is the key to add to a project. It has the fields of corellium.ProjectKey the API
+// requires, and the label the API client doesn't have.
+type V1ProjectKeyParametersManual struct {
+	Kind  string `json:"kind"`
+	Label string `json:"label"`
+	Key   string `json:"key"`
+}
+
+// newV1ProjectKeyParametersManual returns the parameters of the project key of the model.
+func newV1ProjectKeyParametersManual(m V1ProjectKeyModel) V1ProjectKeyParametersManual {
+	return V1ProjectKeyParametersManual{
+		Kind:  m.Kind.ValueString(),
+		Label: m.Label.ValueString(),
+		Key:   m.Key.ValueString(),
+	}
+}
+
+// V1ProjectKeyManual is a project key with the label the API client doesn't have.
+type V1ProjectKeyManual struct {
+	corellium.ProjectKey
+	Label string `json:"label"`
+}
+
+// V1AddProjectKeyManual adds the key to the project, with the label the API client doesn't have.
+func V1AddProjectKeyManual(ctx context.Context, cfg *corellium.Configuration, projectId string, params V1ProjectKeyParametersManual) (*V1ProjectKeyManual, *http.Response, error) {
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, resp, err := doManualRequest(ctx, cfg, http.MethodPost, "/api/v1/projects/"+url.PathEscape(projectId)+"/keys", bytes.NewReader(payload))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var key V1ProjectKeyManual
+	if err := json.Unmarshal(b, &key); err != nil {
+		return nil, resp, err
+	}
+
+	return &key, resp, nil
+}
+
+// V1GetProjectKeysManual returns the keys of the project, with the labels the API client doesn't have.
+func V1GetProjectKeysManual(ctx context.Context, cfg *corellium.Configuration, projectId string) ([]V1ProjectKeyManual, *http.Response, error) {
+	b, resp, err := doManualRequest(ctx, cfg, http.MethodGet, "/api/v1/projects/"+url.PathEscape(projectId)+"/keys", nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var keys []V1ProjectKeyManual
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return nil, resp, err
+	}
+
+	return keys, resp, nil
+}
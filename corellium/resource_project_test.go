@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccCorelliumV1ProjectResource_basic(t *testing.T) {
@@ -535,6 +536,13 @@ func TestAccCorelliumV1ProjectResource_keys(t *testing.T) {
 					resource.TestCheckResourceAttr("corellium_v1project.test", "keys.0.key", "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFD33iT/L6sIb3kUWNMg2q9IbIF0DzksIRXJt4BbaP3K"),
 				),
 			},
+			{
+				ResourceName:      "corellium_v1project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// NOTICE: The API doesn't return when the project was created nor updated, so they aren't imported.
+				ImportStateVerifyIgnore: []string{"created_at", "updated_at"},
+			},
 			{
 				Config: providerConfig + `
 				resource "corellium_v1project" "test" {
@@ -637,3 +645,54 @@ func TestAccCorelliumV1ProjectResource_non_enterprise(t *testing.T) {
 		},
 	})
 }
+
+func TestAccCorelliumV1ProjectResource_deleted_outside(t *testing.T) {
+	var id string
+
+	config := providerConfig + `
+                resource "corellium_v1project" "test" {
+                    name = "test_deleted_outside"
+                    settings = {
+                        version = 1
+                        internet_access = false
+                        dhcp = false
+                    }
+                    quotas = {
+                        cores = 1
+                    }
+                    users = []
+                    teams = []
+					keys = []
+                }
+                `
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					rs, ok := s.RootModule().Resources["corellium_v1project.test"]
+					if !ok {
+						return fmt.Errorf("project not found in the state")
+					}
+
+					id = rs.Primary.ID
+					return nil
+				},
+			},
+			{
+				// The project is deleted outside of Terraform, so it must be planned to be created again.
+				PreConfig: func() {
+					auth, client := testAccClient()
+					if _, err := client.ProjectsApi.V1DeleteProject(auth, id).Execute(); err != nil {
+						t.Fatalf("unable to delete the project: %s", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -2,20 +2,26 @@ package corellium
 
 import (
 	"context"
-	"io"
-	"net/http"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"terraform-provider-corellium/corellium/pkg/api"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &CorelliumV1SnapshotResource{}
-	_ resource.ResourceWithConfigure = &CorelliumV1SnapshotResource{}
+	_ resource.Resource                = &CorelliumV1SnapshotResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1SnapshotResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1SnapshotResource{}
 )
 
 // NewCorelliumV1SnapshotResource is a helper function to simplify the provider implementation.
@@ -54,8 +60,27 @@ type V1SnapshotModel struct {
 	// Live snapshot (included state and memory).
 	Live  types.Bool `tfsdk:"live"`
 	Local types.Bool `tfsdk:"local"`
+	// RestoreOnDestroy restores the instance to the snapshot before the snapshot is deleted.
+	RestoreOnDestroy types.Bool `tfsdk:"restore_on_destroy"`
+	// RestoreTrigger is an arbitrary value that, when changed, restores the instance to the snapshot.
+	RestoreTrigger types.String `tfsdk:"restore_trigger"`
+	// Timeouts is the time to wait for the snapshot to be created, renamed and deleted, and for the instance to be
+	// restored to it.
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
+const (
+	// V1SnapshotDefaultCreateTimeout is the default time to wait for a snapshot to be created.
+	V1SnapshotDefaultCreateTimeout = 20 * time.Minute
+	// V1SnapshotDefaultUpdateTimeout is the default time to wait for a snapshot to be renamed.
+	V1SnapshotDefaultUpdateTimeout = 1 * time.Minute
+	// V1SnapshotDefaultDeleteTimeout is the default time to wait for a snapshot to be deleted.
+	V1SnapshotDefaultDeleteTimeout = 5 * time.Minute
+	// V1SnapshotDefaultRestoreTimeout is the default time to wait for the instance to be restored to a snapshot, on
+	// update or on destroy, what replaces the update and delete defaults.
+	V1SnapshotDefaultRestoreTimeout = 20 * time.Minute
+)
+
 // Metadata returns the resource type name.
 func (d *CorelliumV1SnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_v1snapshot"
@@ -65,7 +90,7 @@ func (d *CorelliumV1SnapshotResource) Metadata(_ context.Context, req resource.M
 }
 
 // Schema defines the schema for the resource.
-func (d *CorelliumV1SnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (d *CorelliumV1SnapshotResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -111,6 +136,21 @@ func (d *CorelliumV1SnapshotResource) Schema(_ context.Context, _ resource.Schem
 				Description: "Snapshot local",
 				Computed:    true,
 			},
+			"restore_on_destroy": schema.BoolAttribute{
+				Description: "Restore the instance to the snapshot before the snapshot is destroyed",
+				Optional:    true,
+			},
+			"restore_trigger": schema.StringAttribute{
+				Description: "Arbitrary value that, when changed, restores the instance to the snapshot",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -125,34 +165,71 @@ func (d *CorelliumV1SnapshotResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Create(ctx, V1SnapshotDefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	o := corellium.NewSnapshotCreationOptions(plan.Name.ValueString())
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
-	snapshot, r, err := d.client.SnapshotsApi.V1CreateSnapshot(auth, plan.Instance.ValueString()).SnapshotCreationOptions(*o).Execute()
+	created, r, err := d.client.SnapshotsApi.V1CreateSnapshot(auth, plan.Instance.ValueString()).SnapshotCreationOptions(*o).Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Error creating snapshot",
-				"You don't have permissions to create snapshots.",
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error creating snapshot", "You don't have permissions to create snapshots", apiErr)
 			return
 		}
 
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating snapshot",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
+		addAPIError(&resp.Diagnostics, "Error creating snapshot", "An unexpected error was encountered trying to create the snapshot", apiErr)
+		return
+	}
+
+	plan.Id = types.StringValue(created.GetId())
+
+	// NOTICE: The snapshot is saved in the state before waiting for it, so it isn't orphaned when the wait fails.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	const (
+		creating = "creating"
+		done     = "created"
+	)
+
+	createStateConf := &retry.StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			snapshot, r, err := d.client.SnapshotsApi.V1GetSnapshot(auth, created.GetId()).Execute()
+			if err != nil {
+				return nil, "", NewAPIError(r, err)
+			}
+
+			if !snapshot.Status.GetCreated() {
+				return snapshot, creating, nil
+			}
 
+			return snapshot, done, nil
+		},
+		Pending:    []string{creating},
+		Target:     []string{done},
+		Delay:      pollWait(5 * time.Second),
+		MinTimeout: pollWait(5 * time.Second),
+		Timeout:    timeout,
+	}
+
+	raw, err := createStateConf.WaitForStateContext(ctx)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating snapshot",
-			"An unexpected error was encountered trying to create the snapshot:\n\n"+string(b),
+			"Coudn't wait for the snapshot to be created: "+err.Error(),
 		)
 		return
 	}
 
+	snapshot := raw.(*corellium.Snapshot)
+
 	plan.Id = types.StringValue(snapshot.GetId())
 	plan.Name = types.StringValue(snapshot.GetName())
 	plan.Instance = types.StringValue(snapshot.GetInstance())
@@ -185,19 +262,14 @@ func (d *CorelliumV1SnapshotResource) Read(ctx context.Context, req resource.Rea
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	snapshot, r, err := d.client.SnapshotsApi.V1GetSnapshot(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error get the snapshot",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The snapshot was deleted outside of Terraform, so it's removed from the state to be created again.
+			resp.State.RemoveResource(ctx)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error to get the snapshot",
-			"An unexpected error was encountered trying to create the snapshot:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error to get the snapshot", "An unexpected error was encountered trying to read the snapshot", apiErr)
 		return
 	}
 
@@ -213,6 +285,7 @@ func (d *CorelliumV1SnapshotResource) Read(ctx context.Context, req resource.Rea
 	state.Live = types.BoolValue(snapshot.GetLive())
 	state.Local = types.BoolValue(snapshot.GetLocal())
 
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -246,30 +319,46 @@ func (d *CorelliumV1SnapshotResource) Update(ctx context.Context, req resource.U
 		)
 	}
 
+	// NOTICE: Only a change to a set trigger restores the instance, so removing the trigger doesn't.
+	restore := !plan.RestoreTrigger.IsNull() && !plan.RestoreTrigger.Equal(state.RestoreTrigger)
+
+	defaultTimeout := V1SnapshotDefaultUpdateTimeout
+	if restore {
+		defaultTimeout = V1SnapshotDefaultRestoreTimeout
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	o := corellium.NewSnapshotCreationOptions(plan.Name.ValueString())
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	if !state.Name.Equal(plan.Name) {
 		snapshot, r, err := d.client.SnapshotsApi.V1SnapshotRename(auth, state.Id.ValueString()).SnapshotCreationOptions(*o).Execute()
 		if err != nil {
-			b, err := io.ReadAll(r.Body)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error updating snapshot",
-					"Coudn't read the response body: "+err.Error(),
-				)
-				return
-			}
-
-			resp.Diagnostics.AddError(
-				"Error updating snapshot",
-				"An unexpected error was encountered trying to update the snapshot:\n\n"+string(b),
-			)
+			addAPIError(&resp.Diagnostics, "Error updating snapshot", "An unexpected error was encountered trying to update the snapshot", NewAPIError(r, err))
 			return
 		}
 
 		state.Name = types.StringValue(snapshot.GetName())
 	}
 
+	if restore {
+		if err := d.restoreInstance(ctx, auth, state.Instance.ValueString(), state.Id.ValueString(), timeout); err != nil {
+			addRestoreError(&resp.Diagnostics, "Error updating snapshot", err)
+			return
+		}
+	}
+
+	state.RestoreOnDestroy = plan.RestoreOnDestroy
+	state.RestoreTrigger = plan.RestoreTrigger
+	state.Timeouts = plan.Timeouts
+
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -286,23 +375,117 @@ func (d *CorelliumV1SnapshotResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
+	restore := state.RestoreOnDestroy.ValueBool()
+
+	defaultTimeout := V1SnapshotDefaultDeleteTimeout
+	if restore {
+		defaultTimeout = V1SnapshotDefaultRestoreTimeout
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
+
+	if restore {
+		err := d.restoreInstance(ctx, auth, state.Instance.ValueString(), state.Id.ValueString(), timeout)
+		// NOTICE: When the instance, or the snapshot, was already deleted, there is nothing to restore.
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			addRestoreError(&resp.Diagnostics, "Error deleting snapshot", err)
+			return
+		}
+	}
+
 	r, err := d.client.SnapshotsApi.V1DeleteSnapshot(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting snapshot",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		addAPIError(&resp.Diagnostics, "Unable to delete snapshot", "An unexpected error was encountered trying to delete the snapshot", NewAPIError(r, err))
+		return
+	}
+
+	const (
+		deleting = "deleting"
+		deleted  = "deleted"
+	)
+
+	deleteStateConf := &retry.StateChangeConf{
+		Refresh: func() (interface{}, string, error) {
+			snapshot, r, err := d.client.SnapshotsApi.V1GetSnapshot(auth, state.Id.ValueString()).Execute()
+			if err != nil {
+				apiErr := NewAPIError(r, err)
+				if errors.Is(apiErr, ErrNotFound) {
+					return state.Id.ValueString(), deleted, nil
+				}
+
+				return nil, "", apiErr
+			}
+
+			return snapshot, deleting, nil
+		},
+		Pending:    []string{deleting},
+		Target:     []string{deleted},
+		Delay:      pollWait(1 * time.Second),
+		MinTimeout: pollWait(1 * time.Second),
+		Timeout:    timeout,
+	}
+
+	if _, err := deleteStateConf.WaitForStateContext(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting snapshot",
+			"An unexpected error was encountered trying to delete the snapshot:\n\n"+err.Error(),
+		)
+		return
+	}
+}
+
+// restoreInstance restores the instance to the snapshot, and waits until the instance finishes restoring it.
+func (d *CorelliumV1SnapshotResource) restoreInstance(ctx context.Context, auth context.Context, instanceId, snapshotId string, timeout time.Duration) error {
+	r, err := d.client.SnapshotsApi.V1RestoreInstanceSnapshot(auth, instanceId, snapshotId).Execute()
+	if err != nil {
+		return NewAPIError(r, err)
+	}
+
+	_, err = waitForInstanceTask(ctx, d.client, auth, instanceId, timeout)
+	return err
+}
+
+// addRestoreError appends the error returned by restoreInstance to the diagnostics.
+func addRestoreError(diags *diag.Diagnostics, summary string, err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(diags, summary, "You don't have permission to restore the instance to the snapshot", apiErr)
 			return
 		}
 
+		addAPIError(diags, summary, "An unexpected error was encountered trying to restore the instance to the snapshot", apiErr)
+		return
+	}
+
+	diags.AddError(summary, "Coudn't wait for the instance to be restored to the snapshot: "+err.Error())
+}
+
+// ImportState imports an existing snapshot into the Terraform state.
+// The import ID can be either the snapshot ID or a composite ID in the format `instance_id/snapshot_id`.
+func (d *CorelliumV1SnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !strings.Contains(req.ID, "/") {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	ids := strings.Split(req.ID, "/")
+	if len(ids) != 2 || ids[0] == "" || ids[1] == "" {
 		resp.Diagnostics.AddError(
-			"Unable to delete snapshot",
-			"An unexpected error was encountered trying to delete the snapshot:\n\n"+string(b))
+			"Unexpected import identifier",
+			"Expected import identifier with format: instance_id/snapshot_id. Got: "+req.ID,
+		)
 		return
 	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[1])...)
 }
 
 // Configure adds the provider configured client to the resource.
@@ -4,14 +4,17 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccCorelliumV1SnapshotResource(t *testing.T) {
-	t.Skip("Skipping snapshot resource tests")
+	if testAccMock == nil {
+		t.Skip("Skipping snapshot resource tests")
+	}
 
 	projectConfig := `
     resource "corellium_v1project" "test" {
-        name = "test"
+        name = "` + t.Name() + `"
         settings = {
             version = 1
             internet_access = true
@@ -33,11 +36,13 @@ func TestAccCorelliumV1SnapshotResource(t *testing.T) {
         project = corellium_v1project.test.id
         os = "15.7.5"
         wait_for_ready = true
-        wait_for_ready_timeout = 600
+        timeouts {
+            create = "10m"
+        }
     }
     `
 
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
@@ -62,6 +67,45 @@ func TestAccCorelliumV1SnapshotResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("corellium_v1snapshot.test", "instance"),
 				),
 			},
+			{
+				ResourceName:      "corellium_v1snapshot.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "corellium_v1snapshot.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["corellium_v1snapshot.test"]
+					return rs.Primary.Attributes["instance"] + "/" + rs.Primary.ID, nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig + `
+                resource "corellium_v1snapshot" "test" {
+                    name = "test_updat"
+                    instance = corellium_v1instance.test.id
+                    restore_trigger = "1"
+                    restore_on_destroy = true
+                }`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1snapshot.test", "restore_trigger", "1"),
+					resource.TestCheckResourceAttr("corellium_v1snapshot.test", "restore_on_destroy", "true"),
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig + `
+                resource "corellium_v1snapshot" "test" {
+                    name = "test_updat"
+                    instance = corellium_v1instance.test.id
+                    restore_trigger = "2"
+                    restore_on_destroy = true
+                }`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1snapshot.test", "restore_trigger", "2"),
+				),
+			},
 		},
 	})
 }
@@ -69,7 +113,7 @@ func TestAccCorelliumV1SnapshotResource(t *testing.T) {
 func TestAccCorelliumV1SnapshotResource_non_enterprise(t *testing.T) {
 	t.Skip("Skipping enterprise snapshot resource tests")
 
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
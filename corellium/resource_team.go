@@ -2,10 +2,10 @@ package corellium
 
 import (
 	"context"
-	"io"
-	"net/http"
+	"errors"
 
 	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -14,8 +14,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &CorelliumV1TeamResource{}
-	_ resource.ResourceWithConfigure = &CorelliumV1TeamResource{}
+	_ resource.Resource                = &CorelliumV1TeamResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1TeamResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1TeamResource{}
 )
 
 // NewCorelliumV1TeamResource is a helper function to simplify the provider implementation.
@@ -94,19 +95,7 @@ func (d *CorelliumV1TeamResource) Create(ctx context.Context, req resource.Creat
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error listing teams",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error listing teams",
-			"An unexpected error was encountered trying to list the teams:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error listing teams", "An unexpected error was encountered trying to list the teams", NewAPIError(r, err))
 		return
 	}
 
@@ -123,27 +112,13 @@ func (d *CorelliumV1TeamResource) Create(ctx context.Context, req resource.Creat
 	t := corellium.NewCreateTeam(plan.Label.ValueString())
 	team, r, err := d.client.TeamsApi.V1TeamCreate(auth).CreateTeam(*t).Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Error creating team",
-				"You don't have permissions to create a team",
-			)
-			return
-		}
-
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating team",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error creating team", "You don't have permissions to create a team", apiErr)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error creating team",
-			"An unexpected error was encountered trying to create the team:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error creating team", "An unexpected error was encountered trying to create the team", apiErr)
 		return
 	}
 
@@ -158,39 +133,13 @@ func (d *CorelliumV1TeamResource) Create(ctx context.Context, req resource.Creat
 			revert := func() {
 				r, err := d.client.TeamsApi.V1TeamDelete(auth, team.GetId()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error deleting team",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Unable to delete team",
-						"An unexpected error was encountered trying to delete the team:\n\n"+string(b))
+					addAPIError(&resp.Diagnostics, "Unable to delete team", "An unexpected error was encountered trying to delete the team", NewAPIError(r, err))
 					return
 				}
 			}
 
 			if err != nil {
-				b, err := io.ReadAll(r.Body)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error adding user to team",
-						"Coudn't read the response body: "+err.Error(),
-					)
-
-					revert()
-
-					return
-				}
-
-				resp.Diagnostics.AddError(
-					"Error adding user to team",
-					"An unexpected error was encountered trying to add user to team:\n\n"+string(b),
-				)
+				addAPIError(&resp.Diagnostics, "Error adding user to team", "An unexpected error was encountered trying to add user to team", NewAPIError(r, err))
 
 				revert()
 
@@ -221,19 +170,7 @@ func (d *CorelliumV1TeamResource) Read(ctx context.Context, req resource.ReadReq
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error get the teams",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error to get the teams",
-			"An unexpected error was encountered trying to create the team:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error to get the teams", "An unexpected error was encountered trying to create the team", NewAPIError(r, err))
 		return
 	}
 
@@ -242,6 +179,13 @@ func (d *CorelliumV1TeamResource) Read(ctx context.Context, req resource.ReadReq
 		if team.GetId() == state.Id.ValueString() {
 			state.Id = types.StringValue(team.Id)
 			state.Label = types.StringValue(team.Label)
+
+			// When the users attribute isn't set and the team has no users, we keep it as null to avoid a diff
+			// between the configuration and the state.
+			if state.Users == nil && len(team.Users) == 0 {
+				break
+			}
+
 			state.Users = make([]V1TeamUserModel, len(team.Users))
 			// TODO: add the user model instead of the only ID.
 			for i, user := range team.Users {
@@ -252,6 +196,7 @@ func (d *CorelliumV1TeamResource) Read(ctx context.Context, req resource.ReadReq
 		}
 	}
 
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -283,19 +228,7 @@ func (d *CorelliumV1TeamResource) Update(ctx context.Context, req resource.Updat
 		t := corellium.NewCreateTeam(plan.Label.ValueString())
 		r, err := d.client.TeamsApi.V1TeamChange(auth, state.Id.ValueString()).CreateTeam(*t).Execute()
 		if err != nil {
-			b, err := io.ReadAll(r.Body)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error updating team",
-					"Coudn't read the response body: "+err.Error(),
-				)
-				return
-			}
-
-			resp.Diagnostics.AddError(
-				"Error updating team",
-				"An unexpected error was encountered trying to update the team:\n\n"+string(b),
-			)
+			addAPIError(&resp.Diagnostics, "Error updating team", "An unexpected error was encountered trying to update the team", NewAPIError(r, err))
 			return
 		}
 	}
@@ -317,19 +250,7 @@ func (d *CorelliumV1TeamResource) Update(ctx context.Context, req resource.Updat
 			if !found {
 				r, err := d.client.TeamsApi.V1RemoveUserFromTeam(auth, state.Id.ValueString(), user.Id.ValueString()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error removing user from team",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error removing user from team",
-						"An unexpected error was encountered trying to remove user from team:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error removing user from team", "An unexpected error was encountered trying to remove user from team", NewAPIError(r, err))
 					return
 				}
 
@@ -370,19 +291,7 @@ func (d *CorelliumV1TeamResource) Update(ctx context.Context, req resource.Updat
 			if !found {
 				r, err := d.client.TeamsApi.V1AddUserToTeam(auth, state.Id.ValueString(), user.Id.ValueString()).Execute()
 				if err != nil {
-					b, err := io.ReadAll(r.Body)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							"Error adding user to team",
-							"Coudn't read the response body: "+err.Error(),
-						)
-						return
-					}
-
-					resp.Diagnostics.AddError(
-						"Error adding user to team",
-						"An unexpected error was encountered trying to add user to team:\n\n"+string(b),
-					)
+					addAPIError(&resp.Diagnostics, "Error adding user to team", "An unexpected error was encountered trying to add user to team", NewAPIError(r, err))
 					return
 				}
 
@@ -412,22 +321,16 @@ func (d *CorelliumV1TeamResource) Delete(ctx context.Context, req resource.Delet
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	r, err := d.client.TeamsApi.V1TeamDelete(auth, state.Id.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error deleting team",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Unable to delete team",
-			"An unexpected error was encountered trying to delete the team:\n\n"+string(b))
+		addAPIError(&resp.Diagnostics, "Unable to delete team", "An unexpected error was encountered trying to delete the team", NewAPIError(r, err))
 		return
 	}
 }
 
+// ImportState imports an existing team into the Terraform state using its ID.
+func (d *CorelliumV1TeamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
 // Configure adds the provider configured client to the resource.
 func (d *CorelliumV1TeamResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
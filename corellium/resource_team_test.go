@@ -160,6 +160,11 @@ func TestAccCorelliumV1TeamResource(t *testing.T) {
 					resource.TestCheckResourceAttr("corellium_v1team.test", "users.#", "2"),
 				),
 			},
+			{
+				ResourceName:      "corellium_v1team.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -2,9 +2,10 @@ package corellium
 
 import (
 	"context"
-	"net/http"
+	"errors"
 
 	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -13,8 +14,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &CorelliumV1UserResource{}
-	_ resource.ResourceWithConfigure = &CorelliumV1UserResource{}
+	_ resource.Resource                = &CorelliumV1UserResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1UserResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1UserResource{}
 )
 
 // NewCorelliumV1UserResource is a helper function to simplify the provider implementation.
@@ -103,18 +105,13 @@ func (d *CorelliumV1UserResource) Create(ctx context.Context, req resource.Creat
 	// Just returns a map[string]interface{} with the user ID
 	createdUser, r, err := d.client.UsersApi.V1CreateUser(auth).Body(userMap).Execute()
 	if err != nil {
-		if r.StatusCode == http.StatusForbidden {
-			resp.Diagnostics.AddError(
-				"Unable to create the corellium user",
-				"You do not have permission to create a user",
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Unable to create the corellium user", "You do not have permission to create a user", apiErr)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Unable to create the corellium user",
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Unable to create the corellium user", "An unexpected error was encountered trying to create the user", apiErr)
 		return
 	}
 
@@ -149,23 +146,24 @@ func (d *CorelliumV1UserResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
-	teams, _, err := d.client.TeamsApi.V1Teams(auth).Execute()
+	teams, r, err := d.client.TeamsApi.V1Teams(auth).Execute()
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to fetch the corellium teams",
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Unable to fetch the corellium teams", "An unexpected error was encountered trying to read the teams", NewAPIError(r, err))
 		return
 	}
 
 	// Iterate over the teams and find the user
+	found := false
 	for _, team := range teams {
 		for _, user := range team.Users {
 			if user.Id == state.ID.ValueString() {
+				found = true
 				state.Name = types.StringValue(user.GetName())
 				state.Label = types.StringValue(user.GetLabel())
 				state.Email = types.StringValue(user.GetEmail())
-				if !user.Administrator.IsSet() {
+				// NOTICE: The API doesn't always return the administrator flag, so we only override it when it's
+				// returned, or when the state doesn't have it yet, e.g. when the user is being imported.
+				if user.Administrator.IsSet() || state.Administrator.IsNull() {
 					state.Administrator = types.BoolValue(user.GetAdministrator())
 				}
 				break
@@ -173,6 +171,13 @@ func (d *CorelliumV1UserResource) Read(ctx context.Context, req resource.ReadReq
 		}
 	}
 
+	// NOTICE: There isn't an endpoint to get a single user, but every user is in the all-users team, so a user that
+	// isn't in any team was deleted outside of Terraform, and it's removed from the state to be created again.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -211,13 +216,10 @@ func (d *CorelliumV1UserResource) Update(ctx context.Context, req resource.Updat
 	// Update the user
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	// Takes the user uuID as a parameter and a map[string]interface{} as a body containing the user data to update
-	_, _, err := d.client.UsersApi.V1UpdateUser(auth, state.ID.ValueString()).Body(updatedUserMap).Execute()
+	_, r, err := d.client.UsersApi.V1UpdateUser(auth, state.ID.ValueString()).Body(updatedUserMap).Execute()
 	// Returns an empty body and a 200 status code on success
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to update the corellium user: "+state.ID.ValueString(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Unable to update the corellium user: "+state.ID.ValueString(), "An unexpected error was encountered trying to update the user", NewAPIError(r, err))
 		return
 	}
 
@@ -249,16 +251,18 @@ func (d *CorelliumV1UserResource) Delete(ctx context.Context, req resource.Delet
 	// Delete the user
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	// Takes the user uuID as a parameter
-	_, _, err := d.client.UsersApi.V1DeleteUser(auth, state.ID.ValueString()).Execute()
+	_, r, err := d.client.UsersApi.V1DeleteUser(auth, state.ID.ValueString()).Execute()
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to delete the corellium user: "+state.ID.ValueString(),
-			err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Unable to delete the corellium user: "+state.ID.ValueString(), "An unexpected error was encountered trying to delete the user", NewAPIError(r, err))
 		return
 	}
 }
 
+// ImportState imports an existing user into the Terraform state using its ID.
+func (d *CorelliumV1UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
 // Configure adds the provider configured client to the resource.
 func (d *CorelliumV1UserResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
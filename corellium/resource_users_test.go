@@ -60,6 +60,12 @@ func TestAccCorelliumV1Users(t *testing.T) {
 					resource.TestCheckNoResourceAttr("corellium_v1user.test", "ID"),
 				),
 			},
+			{
+				ResourceName:            "corellium_v1user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
 		},
 	})
 }
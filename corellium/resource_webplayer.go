@@ -1,25 +1,36 @@
 package corellium
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"io"
 	"net/http"
-	"terraform-provider-corellium/corellium/pkg/api"
+	"net/url"
+	"time"
 
 	"github.com/aimoda/go-corellium-api-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-corellium/corellium/pkg/api"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &CorelliumV1WebPlayerResource{}
-	_ resource.ResourceWithConfigure = &CorelliumV1WebPlayerResource{}
+	_ resource.Resource                = &CorelliumV1WebPlayerResource{}
+	_ resource.ResourceWithConfigure   = &CorelliumV1WebPlayerResource{}
+	_ resource.ResourceWithImportState = &CorelliumV1WebPlayerResource{}
+	_ resource.ResourceWithModifyPlan  = &CorelliumV1WebPlayerResource{}
 )
 
 // helper function to simplify the provider implementation.
@@ -38,13 +49,17 @@ type V1WebPlayerDataModel struct {
 	Identifier types.String `tfsdk:"identifier"`
 	Project    types.String `tfsdk:"project"`
 	Token      types.String `tfsdk:"token"`
-	// Expiration       types.String  `tfsdk:"expiration"`
+	// Expiration is the time the session expires, in RFC3339 format.
+	Expiration       types.String  `tfsdk:"expiration"`
 	Expiresinseconds types.Float64 `tfsdk:"expiresinseconds"`
-	ClientId         types.String  `tfsdk:"clientid"`
+	// RenewBefore is the remaining lifetime, in seconds, below which the session is replaced by a new one.
+	RenewBefore types.Float64 `tfsdk:"renew_before"`
+	ClientId    types.String  `tfsdk:"clientid"`
 	// LastActivity types.String  `tfsdk:"lastactivity"`
 	// CreatedAt    types.String  `tfsdk:"createdat"`
 	// UpdatedAt    types.String  `tfsdk:"updatedat"`
-	Features Features `tfsdk:"features"`
+	// Features is nil until Read fills it from the session, when the session is imported.
+	Features *Features `tfsdk:"features"`
 }
 
 type Features struct {
@@ -86,6 +101,9 @@ func (d *CorelliumV1WebPlayerResource) Schema(_ context.Context, _ resource.Sche
 			},
 			"instanceid": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"identifier": schema.StringAttribute{
 				Required: false,
@@ -94,17 +112,37 @@ func (d *CorelliumV1WebPlayerResource) Schema(_ context.Context, _ resource.Sche
 			},
 			"project": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"expiresinseconds": schema.Float64Attribute{
 				Required: true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"renew_before": schema.Float64Attribute{
+				Description: "The remaining lifetime, in seconds, below which the session is replaced by a new one on the next apply",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"expiration": schema.StringAttribute{
+				Description: "The time the session expires, in RFC3339 format",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			// "expiration": schema.StringAttribute{
-			// 	Computed: true,
-			// },
 			"clientid": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
 				Default:  stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"token": schema.StringAttribute{
 				// Sensitive: true,
@@ -123,63 +161,106 @@ func (d *CorelliumV1WebPlayerResource) Schema(_ context.Context, _ resource.Sche
 			// },
 			"features": schema.SingleNestedAttribute{
 				Required: true,
+				// NOTICE: There is no endpoint to update a session, so a session with other features is a new session.
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				// NOTICE: The API disables the features that aren't set, so they default to false, like Read returns them.
 				Attributes: map[string]schema.Attribute{
 					"apps": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"console": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"coretrace": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"devicecontrol": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"devicedelete": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"files": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"frida": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"images": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"messaging": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"netmon": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"network": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"portforwarding": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"profile": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"sensors": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"settings": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"snapshots": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"strace": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"system": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 					"connect": schema.BoolAttribute{
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
 					},
 				},
 			},
@@ -222,19 +303,7 @@ func (d *CorelliumV1WebPlayerResource) Create(ctx context.Context, req resource.
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	instance, r, err := d.client.InstancesApi.V1GetInstance(auth, state.InstanceId.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error get the instance",
-				"Coudn't read the response body: "+err.Error(),
-			)
-			return
-		}
-
-		resp.Diagnostics.AddError(
-			"Error to get the instance",
-			"An unexpected error was encountered trying to get the instance:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error to get the instance", "An unexpected error was encountered trying to get the instance", NewAPIError(r, err))
 		return
 	}
 
@@ -253,70 +322,25 @@ func (d *CorelliumV1WebPlayerResource) Create(ctx context.Context, req resource.
 		*webPlayerFeatures,
 	)
 
-	session, r, err := d.client.WebPlayerApi.V1WebPlayerCreateSession(auth).WebPlayerCreateSessionRequest(*webPlayerRequest).Execute()
+	session, r, err := V1CreateWebPlayerSessionManual(auth, d.client.GetConfig(), *webPlayerRequest)
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-            if r.StatusCode == http.StatusForbidden {
-                resp.Diagnostics.AddError(
-                    "Error creating a web player session",
-                    "You don't have permission to create a web player session",
-                )
-                return
-            }
-			resp.Diagnostics.AddError(
-				"Error creating a web player session",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrForbidden) {
+			addAPIError(&resp.Diagnostics, "Error creating a web player session", "You don't have permission to create a web player session", apiErr)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error creating a web player session",
-			"An unexpected error was encountered trying to create the web player session:\n\n"+string(b),
-		)
+		addAPIError(&resp.Diagnostics, "Error creating a web player session", "An unexpected error was encountered trying to create the web player session", apiErr)
 		return
 	}
 
-	// fmt.Println("*********************************")
-	// SESSION.EXPIRATION IS 1683227776611   (sent 3600 got 1683227776611)
-	// expirationTimestamp := float64(session.GetExpiration()) // 1683227776611
-	// seconds := int64(expirationTimestamp / 1000)
-	// nanoseconds := int64((expirationTimestamp - float64(seconds)*1000) * 1e6)
-	// expirationTime := time.Unix(seconds, nanoseconds)
-	// expirationTime := time.Unix(seconds, 0)
-
-	// Note: depending on how
-	// expiration := expirationTime.UTC().Round(time.Second).Format("2006-01-02T15:04:05.000Z07:00")
-	// expiration := expirationTime.UTC().Format("2006-01-02T15:04:05.000Z07:00")
-
-	// SESSION.EXPIRATION IS 1683227776611   (sent 3600 got 1683227776611)
-	// expirationTimestamp := float64(session.GetExpiration()) // 1683227776611
-	// seconds := int64(expirationTimestamp / 1000)
-	// nanoseconds := int64((expirationTimestamp - float64(seconds)*1000) * 1e6)
-	// expirationTime := time.Unix(seconds, 0)
-	// expiration := expirationTime.UTC().Format("2006-01-02T15:04:05.000Z07:00")
-	// fmt.Println(expirationTimestamp)
-	// fmt.Println(seconds)
-	// fmt.Println(nanoseconds)
-	// fmt.Println(expirationTime)
-	// fmt.Println(expiration)
-	// fmt.Println(r)
-	state.Identifier = types.StringValue(session.GetIdentifier())
+	state.Identifier = types.StringValue(session.Identifier)
 	state.ID = state.Identifier
-	state.Token = types.StringValue(session.GetToken())
+	state.Token = types.StringValue(session.Token)
 	state.ClientId = types.StringValue(state.ClientId.ValueString())
-
-	//returns a float value for create endpoint but a string for in ISO-8601 format e.g. 2022-05-06T02:39:23.000Z everywhere else....
-	// expirationTimestamp := float64(session.GetExpiration())
-	// expirationTime := time.Unix(int64(expirationTimestamp), 0)
-	// expiration := expirationTime.UTC().Format(time.RFC3339)
-
-	// state.Expiration = types.StringValue(expiration)
-
-	// expiration := time.Unix().UTC().Format("2022-05-06T02:39:23.000Z")
-	// expiration := strconv.FormatFloat(float64(session.GetExpiration()), 'f', -1, 64)
-	// state.Expiration = types.StringValue(expiration)
+	// NOTICE: The create endpoint returns the expiration in milliseconds since the epoch, but the session information
+	// endpoint returns it in ISO-8601 format, so both are converted to RFC3339.
+	state.Expiration = types.StringValue(webPlayerExpiration(session.Expiration).Format(time.RFC3339))
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -337,65 +361,68 @@ func (d *CorelliumV1WebPlayerResource) Read(ctx context.Context, req resource.Re
 
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 
-	var sessions []V1WebPlayerDataModelManual
-	var err error
-	sessions, err = V1GetWebPlayerManual(auth, "https://moda.enterprise.corellium.com/api", state.Identifier.ValueString())
-
-	// session, r, err := d.client.WebPlayerApi.V1WebPlayerSessionInfo(auth, state.Identifier.ValueString()).Execute()
+	sessions, r, err := V1GetWebPlayerManual(auth, d.client.GetConfig(), state.Identifier.ValueString())
 	if err != nil {
-		// b, err := io.ReadAll(r.Body)
-		// if err != nil {
-		// 	resp.Diagnostics.AddError(
-		// 		"Error retrieving the session",
-		// 		"Coudn't read the response body: "+err.Error(),
-		// 	)
-		// 	return
-		// }
-
-		resp.Diagnostics.AddError(
-			"Unable to fetch the web player session",
-			// "An unexpected error was encountered trying to read the session:\n\n"+err.Error()+"\n\n"+string(b))
-			"An unexpected error was encountered trying to read the session:\n\n"+err.Error())
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The session expired, or was deleted outside of Terraform, so it's removed from the state to be
+			// created again.
+			resp.State.RemoveResource(ctx)
+			return
+		}
 
+		addAPIError(&resp.Diagnostics, "Unable to fetch the web player session", "An unexpected error was encountered trying to read the session", apiErr)
 		return
 	}
 	if len(sessions) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	expiration, err := time.Parse(time.RFC3339, sessions[0].Expiration)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Fetching the web player session returned an empty response",
-			"The API returned an empty array. The web player session may have expired or doesn't exist.")
+			"Unable to parse the web player session expiration",
+			"The API returned an expiration that isn't in ISO-8601 format: "+sessions[0].Expiration,
+		)
+		return
+	}
+
+	if !expiration.After(time.Now()) {
+		// NOTICE: An expired session can't be used anymore, so it's removed from the state to be created again.
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	// Should only have one element
-	// state.InstanceId = sessions.InstanceId
+	state.InstanceId = types.StringValue(sessions[0].InstanceId)
 	state.Token = types.StringValue(sessions[0].Token)
-	// state.Expiration = types.StringValue(sessions[0].Expiration)
+	state.Expiration = types.StringValue(expiration.UTC().Format(time.RFC3339))
 	state.ClientId = types.StringValue(sessions[0].ClientId)
 	state.Identifier = types.StringValue(sessions[0].Identifier)
 	state.Project = types.StringValue(sessions[0].Project)
-	state.Features.Apps = types.BoolValue(sessions[0].Features.Apps)
-	state.Features.Console = types.BoolValue(sessions[0].Features.Console)
-	state.Features.Coretrace = types.BoolValue(sessions[0].Features.Coretrace)
-	state.Features.DeviceControl = types.BoolValue(sessions[0].Features.DeviceControl)
-	state.Features.DeviceDelete = types.BoolValue(sessions[0].Features.DeviceDelete)
-	state.Features.Files = types.BoolValue(sessions[0].Features.Files)
-	state.Features.Frida = types.BoolValue(sessions[0].Features.Frida)
-	state.Features.Images = types.BoolValue(sessions[0].Features.Images)
-	state.Features.Messaging = types.BoolValue(sessions[0].Features.Messaging)
-	state.Features.Netmon = types.BoolValue(sessions[0].Features.Netmon)
-	state.Features.Network = types.BoolValue(sessions[0].Features.Network)
-	state.Features.PortForwarding = types.BoolValue(sessions[0].Features.PortForwarding)
-	state.Features.Profile = types.BoolValue(sessions[0].Features.Profile)
-	state.Features.Sensors = types.BoolValue(sessions[0].Features.Sensors)
-	state.Features.Settings = types.BoolValue(sessions[0].Features.Settings)
-	state.Features.Snapshots = types.BoolValue(sessions[0].Features.Snapshots)
-	state.Features.Strace = types.BoolValue(sessions[0].Features.Strace)
-	state.Features.System = types.BoolValue(sessions[0].Features.System)
-	state.Features.Connect = types.BoolValue(sessions[0].Features.Connect)
-
-	// state.InstanceId = types.StringValue(session.GetIdentifier())
-	// state.Token = types.StringValue(session.GetToken())
-	// state.Expiration = types.Float64Value(float64(session.GetExpiration()))
+	state.Expiresinseconds = types.Float64Value(sessions[0].Expiresinseconds)
+	state.Features = &Features{
+		Apps:           types.BoolValue(sessions[0].Features.Apps),
+		Console:        types.BoolValue(sessions[0].Features.Console),
+		Coretrace:      types.BoolValue(sessions[0].Features.Coretrace),
+		DeviceControl:  types.BoolValue(sessions[0].Features.DeviceControl),
+		DeviceDelete:   types.BoolValue(sessions[0].Features.DeviceDelete),
+		Files:          types.BoolValue(sessions[0].Features.Files),
+		Frida:          types.BoolValue(sessions[0].Features.Frida),
+		Images:         types.BoolValue(sessions[0].Features.Images),
+		Messaging:      types.BoolValue(sessions[0].Features.Messaging),
+		Netmon:         types.BoolValue(sessions[0].Features.Netmon),
+		Network:        types.BoolValue(sessions[0].Features.Network),
+		PortForwarding: types.BoolValue(sessions[0].Features.PortForwarding),
+		Profile:        types.BoolValue(sessions[0].Features.Profile),
+		Sensors:        types.BoolValue(sessions[0].Features.Sensors),
+		Settings:       types.BoolValue(sessions[0].Features.Settings),
+		Snapshots:      types.BoolValue(sessions[0].Features.Snapshots),
+		Strace:         types.BoolValue(sessions[0].Features.Strace),
+		System:         types.BoolValue(sessions[0].Features.System),
+		Connect:        types.BoolValue(sessions[0].Features.Connect),
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -406,7 +433,92 @@ func (d *CorelliumV1WebPlayerResource) Read(ctx context.Context, req resource.Re
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (d *CorelliumV1WebPlayerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	//No update endpoint for the WebPlayerAPI
+	// NOTICE: There is no update endpoint for the WebPlayerAPI, so only renew_before, what doesn't change the session,
+	// is updated in place.
+	var plan, state V1WebPlayerDataModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RenewBefore = plan.RenewBefore
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan replaces the session when its remaining lifetime is below renew_before, so the token stays valid across
+// applies.
+func (d *CorelliumV1WebPlayerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// NOTICE: There is nothing to renew when the session is created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state V1WebPlayerDataModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.RenewBefore.IsNull() || plan.RenewBefore.IsUnknown() || plan.Expiresinseconds.IsUnknown() {
+		return
+	}
+
+	if plan.RenewBefore.ValueFloat64() >= plan.Expiresinseconds.ValueFloat64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("renew_before"),
+			"Invalid renew_before",
+			"The renew_before must be less than expiresinseconds, otherwise the session is replaced on every apply.",
+		)
+		return
+	}
+
+	if state.Expiration.IsNull() || state.Expiration.IsUnknown() {
+		return
+	}
+
+	expiration, err := time.Parse(time.RFC3339, state.Expiration.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expiration"),
+			"Unable to parse the web player session expiration",
+			"The expiration in the state isn't in RFC3339 format: "+state.Expiration.ValueString(),
+		)
+		return
+	}
+
+	renewBefore := time.Duration(plan.RenewBefore.ValueFloat64() * float64(time.Second))
+	if time.Until(expiration) >= renewBefore {
+		return
+	}
+
+	// NOTICE: The expiration is unknown until the new session is created, what differs from the state, so Terraform
+	// replaces the session.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("identifier"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expiration"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expiration"))
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -421,22 +533,21 @@ func (d *CorelliumV1WebPlayerResource) Delete(ctx context.Context, req resource.
 	auth := context.WithValue(ctx, corellium.ContextAccessToken, api.GetAccessToken())
 	r, err := d.client.WebPlayerApi.V1WebPlayerDestroySession(auth, state.Identifier.ValueString()).Execute()
 	if err != nil {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error retrieving the session",
-				"Coudn't read the response body: "+err.Error(),
-			)
+		apiErr := NewAPIError(r, err)
+		if errors.Is(apiErr, ErrNotFound) {
+			// NOTICE: The session already expired, so there is nothing to delete.
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Unable to fetch the web player session",
-			"An unexpected error was encountered trying to read the session:\n\n"+err.Error()+"\n\n"+string(b))
-
+		addAPIError(&resp.Diagnostics, "Unable to delete the web player session", "An unexpected error was encountered trying to delete the session", apiErr)
 		return
 	}
+}
 
+// ImportState imports an existing web player session into the Terraform state using its session ID.
+func (d *CorelliumV1WebPlayerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("identifier"), req.ID)...)
 }
 
 // Configure adds the provider configured client to the resource.
@@ -486,38 +597,55 @@ type FeaturesManual struct {
 	Connect        bool `tfsdk:"connect"`
 }
 
-func V1GetWebPlayerManual(ctx context.Context, url string, sessionId string) ([]V1WebPlayerDataModelManual, error) {
-	client := &http.Client{}
+// webPlayerExpiration returns the time of the expiration returned by the create endpoint, in milliseconds since the
+// epoch.
+func webPlayerExpiration(ms float64) time.Time {
+	return time.UnixMilli(int64(ms)).UTC()
+}
+
+// V1WebPlayerSessionManual is the session returned by the create endpoint. It isn't corellium.WebPlayerSession because
+// its float32 expiration, in milliseconds since the epoch, is off by minutes.
+type V1WebPlayerSessionManual struct {
+	Identifier string  `json:"identifier"`
+	Token      string  `json:"token"`
+	Expiration float64 `json:"expiration"`
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url+"/v1/webplayer/"+sessionId, nil)
+// V1CreateWebPlayerSessionManual creates a web player session, and decodes its expiration without losing precision.
+func V1CreateWebPlayerSessionManual(ctx context.Context, cfg *corellium.Configuration, request corellium.WebPlayerCreateSessionRequest) (*V1WebPlayerSessionManual, *http.Response, error) {
+	payload, err := json.Marshal(request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Get access token from context and add it to the request header
-	accessToken, ok := ctx.Value(corellium.ContextAccessToken).(string)
-	if !ok {
-		return nil, errors.New("access token not found in context")
+	b, resp, err := doManualRequest(ctx, cfg, http.MethodPost, "/api/v1/webplayer", bytes.NewReader(payload))
+	if err != nil {
+		return nil, resp, err
 	}
-	req.Header.Add("Authorization", "Bearer "+accessToken)
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+	var session V1WebPlayerSessionManual
+	if err := json.Unmarshal(b, &session); err != nil {
+		return nil, resp, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("error fetching firmware data: %s", resp.Status)
+	return &session, resp, nil
+}
+
+// V1GetWebPlayerManual gets the information of the web player session. The API returns a list with the session, and
+// its expiration in ISO-8601 format, what the API client can't decode.
+func V1GetWebPlayerManual(ctx context.Context, cfg *corellium.Configuration, sessionId string) ([]V1WebPlayerDataModelManual, *http.Response, error) {
+	b, resp, err := doManualRequest(ctx, cfg, http.MethodGet, "/api/v1/webplayer/"+url.PathEscape(sessionId), nil)
+	if err != nil {
+		return nil, resp, err
 	}
 
 	var sessions []V1WebPlayerDataModelManual
-	err = json.NewDecoder(resp.Body).Decode(&sessions)
+	err = json.Unmarshal(b, &sessions)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	return sessions, nil
+	return sessions, resp, nil
 }
 
 // *******************************************************************************************************************************
@@ -3,17 +3,27 @@ package corellium
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccCorelliumV1WebPlayer(t *testing.T) {
-	t.Skip("The current enterprise account doesn't have WebPlayer enabled. It'll be fixed in the future.")
+	if testAccMock == nil {
+		t.Skip("The current enterprise account doesn't have WebPlayer enabled. It'll be fixed in the future.")
+	}
+
+	// token is the token of the created session, what must not change when the session is refreshed.
+	var token string
+	// expiration is the expiration of the created session, what the refresh must read the same.
+	var expiration string
 
 	projectConfig := `
     resource "corellium_v1project" "test" {
-        name = "test"
+        name = "` + t.Name() + `"
         settings = {
             version = 1
             internet_access = true
@@ -71,6 +81,10 @@ func TestAccCorelliumV1WebPlayer(t *testing.T) {
 		)
 	}
 
+	// renewBeforeConfig is the web player renewed when less than half of its lifetime remains.
+	renewBeforeConfig := strings.Replace(webplayerConfig("corellium_v1project.test.id", "corellium_v1instance.test.id", 3600, true, true, true, true, false, true, false, false, true, true, true, false, true, true, true, true, true, true, true), "expiresinseconds = 3600", "expiresinseconds = 3600\n\t\t\t\trenew_before = 1800", 1)
+
+	// NOTICE: The mock expires the sessions of every instance, so the test doesn't run in parallel with the others.
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -101,15 +115,145 @@ func TestAccCorelliumV1WebPlayer(t *testing.T) {
 					resource.TestCheckResourceAttr("corellium_v1webplayer.test", "features.connect", "true"),
 					resource.TestCheckResourceAttrSet("corellium_v1webplayer.test", "token"),
 					resource.TestCheckResourceAttrSet("corellium_v1webplayer.test", "identifier"),
-					// resource.TestCheckResourceAttrSet("corellium_v1webplayer.test", "expiration"),
+					resource.TestMatchResourceAttr("corellium_v1webplayer.test", "expiration", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+					func(s *terraform.State) error {
+						token = s.RootModule().Resources["corellium_v1webplayer.test"].Primary.Attributes["token"]
+						expiration = s.RootModule().Resources["corellium_v1webplayer.test"].Primary.Attributes["expiration"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig + webplayerConfig("corellium_v1project.test.id", "corellium_v1instance.test.id", 3600, true, true, true, true, false, true, false, false, true, true, true, false, true, true, true, true, true, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if got := s.RootModule().Resources["corellium_v1webplayer.test"].Primary.Attributes["token"]; got != token {
+							return fmt.Errorf("expected the token %q not to change on refresh, got %q", token, got)
+						}
+						if got := s.RootModule().Resources["corellium_v1webplayer.test"].Primary.Attributes["expiration"]; got != expiration {
+							return fmt.Errorf("expected the expiration %q not to change on refresh, got %q", expiration, got)
+						}
+						return nil
+					},
 				),
 			},
+			{
+				ResourceName:      "corellium_v1webplayer.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig + renewBeforeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1webplayer.test", "renew_before", "1800"),
+					resource.TestCheckResourceAttrWith("corellium_v1webplayer.test", "token", func(got string) error {
+						if got != token {
+							return fmt.Errorf("expected the token %q not to change when renew_before is set, got %q", token, got)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// NOTICE: A session that expires within renew_before is planned to be replaced.
+				PreConfig:          func() { testAccMock.ExpireSessionsIn(10 * time.Minute) },
+				Config:             providerConfig + projectConfig + instanceConfig + renewBeforeConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: providerConfig + projectConfig + instanceConfig + renewBeforeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("corellium_v1webplayer.test", "token", func(got string) error {
+						if got == token {
+							return fmt.Errorf("expected the token %q to change when the session is renewed", token)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// NOTICE: An expired session is drift, so the session is planned to be created again.
+				PreConfig:          testAccMock.ExpireSessions,
+				Config:             providerConfig + projectConfig + instanceConfig + webplayerConfig("corellium_v1project.test.id", "corellium_v1instance.test.id", 3600, true, true, true, true, false, true, false, false, true, true, true, false, true, true, true, true, true, true, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestWebPlayerExpiration(t *testing.T) {
+	// NOTICE: A float32 holds the milliseconds since the epoch in steps of about 2 minutes.
+	want := time.Date(2023, 11, 14, 22, 15, 23, 456000000, time.UTC)
+	if got := webPlayerExpiration(float64(want.UnixMilli())); !got.Equal(want) {
+		t.Errorf("expected the expiration %s, got %s", want, got)
+	}
+}
+
+func TestAccCorelliumV1WebPlayer_partial_features(t *testing.T) {
+	if testAccMock == nil {
+		t.Skip("The current enterprise account doesn't have WebPlayer enabled. It'll be fixed in the future.")
+	}
+
+	config := `
+    resource "corellium_v1project" "test" {
+        name = "` + t.Name() + `"
+        settings = {
+            version = 1
+            internet_access = true
+            dhcp = false
+        }
+        quotas = {
+            cores = 2
+        }
+        users = []
+        teams = []
+        keys  = []
+    }
+
+    resource "corellium_v1instance" "test" {
+        name = "test"
+        flavor = "iphone7plus"
+        project = corellium_v1project.test.id
+        os = "15.7.5"
+        wait_for_ready = false
+    }
+
+    resource "corellium_v1webplayer" "test" {
+        project = corellium_v1project.test.id
+        instanceid = corellium_v1instance.test.id
+        expiresinseconds = 3600
+        features = {
+            apps = true
+            console = true
+        }
+    }
+    `
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("corellium_v1webplayer.test", "features.apps", "true"),
+					resource.TestCheckResourceAttr("corellium_v1webplayer.test", "features.console", "true"),
+					resource.TestCheckResourceAttr("corellium_v1webplayer.test", "features.files", "false"),
+					resource.TestCheckResourceAttr("corellium_v1webplayer.test", "features.connect", "false"),
+				),
+			},
+			{
+				// NOTICE: The features that aren't set are read as false, what must not replace the session.
+				Config:   providerConfig + config,
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
 func TestAccCorelliumV1WebPlayer_non_enterprise(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
@@ -0,0 +1 @@
+cpu.x[0] = 0;
@@ -41,12 +41,15 @@ resource "corellium_v1project" "example" {
 }
 
 resource "corellium_v1instance" "example" {
-  project                = corellium_v1project.example.id
-  name                   = "example"
-  flavor                 = "iphone6splus"
-  os                     = "13.6.1"
-  wait_for_ready         = true
-  wait_for_ready_timeout = 900
+  project        = corellium_v1project.example.id
+  name           = "example"
+  flavor         = "iphone6splus"
+  os             = "13.6.1"
+  wait_for_ready = true
+
+  timeouts {
+    create = "15m"
+  }
 
   connection {
     # corellium instances supports ssh and adb connections, but terraform only supports ssh connections, so we can only
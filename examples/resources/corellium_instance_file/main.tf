@@ -0,0 +1,48 @@
+terraform {
+  required_providers {
+    corellium = {
+      source  = "github.com/aimoda/corellium"
+      version = "~> 1.0.0"
+    }
+  }
+
+  backend "s3" {}
+}
+
+provider "corellium" {
+  # placeholder token - replace with real token or use env var CORELLIUM_TOKEN
+  token = ""
+}
+
+resource "corellium_v1project" "example" {
+  name = "example"
+  settings = {
+    version         = 1
+    internet_access = false
+    dhcp            = false
+  }
+  quotas = {
+    cores = 2
+  }
+  teams = []
+  users = []
+  keys  = []
+}
+
+resource "corellium_v1instance" "example" {
+  name     = "example"
+  flavor   = "ranchu"
+  os       = "13.0.0"
+  project  = corellium_v1project.example.id
+  wait_for = "agent"
+
+  timeouts {
+    create = "10m"
+  }
+}
+
+resource "corellium_v1instance_file" "example" {
+  instance = corellium_v1instance.example.id
+  path     = "/data/local/tmp/example.txt"
+  content  = "example"
+}
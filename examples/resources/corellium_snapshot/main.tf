@@ -30,16 +30,23 @@ resource "corellium_v1project" "example" {
 }
 
 resource "corellium_v1instance" "example" {
-  name                   = "example"
-  flavor                 = "iphone7plus"
-  os                     = "15.7.5"
-  project                = corellium_v1project.example.id
-  wait_for_ready         = true
-  wait_for_ready_timeout = 500
+  name           = "example"
+  flavor         = "iphone7plus"
+  os             = "15.7.5"
+  project        = corellium_v1project.example.id
+  wait_for_ready = true
+
+  timeouts {
+    create = "10m"
+  }
 }
 
 resource "corellium_v1snapshot" "example" {
   name     = "example"
   instance = corellium_v1instance.example.id
+
+  # Change the trigger to restore the instance to the snapshot.
+  restore_trigger    = "1"
+  restore_on_destroy = true
 }
 
@@ -40,6 +40,8 @@ resource "corellium_v1webplayer" "example" {
   project          = corellium_v1project.example.id
   instanceid       = corellium_v1instance.example.id
   expiresinseconds = "1800"
+  # Replace the session on the next apply when less than 5 minutes of it remain.
+  renew_before     = 300
   features = {
     apps           = false
     console        = false
@@ -31,6 +31,10 @@ func TestExamples_resources(t *testing.T) {
 			},
 			dir: "./examples/resources/corellium_image",
 		},
+		/*{
+			name: "testing resource hook",
+			dir:  "./examples/resources/corellium_hook",
+		},*/
 		{
 			name: "testing resource instance",
 			dir:  "./examples/resources/corellium_instance",
@@ -43,6 +47,26 @@ func TestExamples_resources(t *testing.T) {
 			name: "testing resource instance with remote-exec provisioner",
 			dir:  "./examples/resources/corellium_instance/remote-exec",
 		},
+		{
+			name: "testing resource instance action",
+			dir:  "./examples/resources/corellium_instance_action",
+		},
+		/*{
+			name: "testing resource instance app",
+			dir:  "./examples/resources/corellium_instance_app",
+		},*/
+		{
+			name: "testing resource instance file",
+			dir:  "./examples/resources/corellium_instance_file",
+		},
+		{
+			name: "testing resource instance sensors",
+			dir:  "./examples/resources/corellium_instance_sensors",
+		},
+		{
+			name: "testing resource instance trace",
+			dir:  "./examples/resources/corellium_instance_trace",
+		},
 		{
 			name: "testing resource project",
 			dir:  "./examples/resources/corellium_project",